@@ -0,0 +1,12 @@
+data "tenablesc_agent_groups" "servers" {
+  # ID of the Nessus Manager scanner the agents are linked to.
+  nessus_manager_id = "3"
+  name_filter       = "servers-(prod|staging)"
+}
+
+resource "tenablesc_agent_scan" "servers" {
+  name              = "Server Agent Scan"
+  repository_id     = data.tenablesc_repository.agents.id
+  nessus_manager_id = "3"
+  agent_group_ids   = keys(data.tenablesc_agent_groups.servers.agent_groups)
+}
@@ -0,0 +1,10 @@
+data "tenablesc_group" "full_access" {
+  name = "Full Access"
+}
+
+resource "tenablesc_user" "analyst" {
+  username  = "jdoe"
+  auth_type = "saml"
+  role_id   = "3"
+  group_id  = data.tenablesc_group.full_access.id
+}
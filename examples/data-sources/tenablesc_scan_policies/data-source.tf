@@ -0,0 +1,7 @@
+data "tenablesc_scan_policies" "compliance" {
+  name_filter = "Compliance - .*"
+}
+
+output "compliance_policy_names" {
+  value = values(data.tenablesc_scan_policies.compliance.scan_policies)
+}
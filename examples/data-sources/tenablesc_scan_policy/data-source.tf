@@ -0,0 +1,13 @@
+data "tenablesc_scan_policy" "basic" {
+  # centrally managed policy, shared with this workspace's group.
+  name = "Basic Network Scan"
+}
+
+resource "tenablesc_scan" "basic" {
+  name = "Nightly Basic Scan"
+
+  repository_id = "1"
+  policy_id     = data.tenablesc_scan_policy.basic.id
+
+  ips_and_names = "10.0.0.0/24"
+}
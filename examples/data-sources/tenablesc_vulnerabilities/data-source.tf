@@ -0,0 +1,23 @@
+data "tenablesc_vulnerabilities" "new_hosts" {
+  tool = "sumip"
+
+  filter {
+    filter_name = "asset"
+    value       = tenablesc_asset.new_hosts.id
+  }
+
+  filter {
+    filter_name = "severity"
+    value       = "4"
+  }
+
+  # Only read once the validation scan has finished.
+  depends_on = [tenablesc_scan_launch.validation]
+
+  lifecycle {
+    postcondition {
+      condition     = self.total_records == 0
+      error_message = "New hosts have critical vulnerabilities: ${join(", ", self.ip_summaries[*].ip)}"
+    }
+  }
+}
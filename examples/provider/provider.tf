@@ -10,6 +10,29 @@ provider "tenablesc" {
   uri        = "https://your_sc_host.dns.name/rest" # may be specified with TENABLESC_URI environment variable
   access_key = ""                                   # may be specified with TENABLESC_ACCESS_KEY environment variable
   secret_key = ""                                   # may be specified with TENABLESC_SECRET_KEY environment variable
+
+  # alternatively, log in with a username and password instead of API keys.
+  # username = "" # may be specified with TENABLESC_USERNAME environment variable
+  # password = "" # may be specified with TENABLESC_PASSWORD environment variable
+
+  # trust an internal PKI without modifying the system trust store.
+  # ca_cert_file = "/etc/pki/internal-ca.pem"
+}
+
+# a single provider for objects requiring either administrator or organization credentials.
+provider "tenablesc" {
+  alias = "dual_scope"
+  uri   = "https://your_sc_host.dns.name/rest"
+
+  admin {
+    access_key = ""
+    secret_key = ""
+  }
+
+  organization {
+    access_key = ""
+    secret_key = ""
+  }
 }
 
 data "tenablesc_repository" "default" {
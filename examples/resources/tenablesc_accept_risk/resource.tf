@@ -25,4 +25,15 @@ resource "tenablesc_accept_risk" "self_signed_certificate" {
   repository_ids = local.repository_ids
   # https://www.tenable.com/plugins/nessus/45411
   plugin_id = "45411"
-}
\ No newline at end of file
+}
+resource "tenablesc_accept_risk" "legacy_tls" {
+  repository_id = local.repository_ids[0]
+  # https://www.tenable.com/plugins/nessus/104743
+  plugin_id = "104743"
+  comments  = "Legacy appliance; revisit quarterly."
+
+  # accepted for 90 days at a time; plans in the last two weeks renew it,
+  # recording who reapproved it in the rule's comments.
+  expires_in   = "90d"
+  renew_before = "2w"
+}
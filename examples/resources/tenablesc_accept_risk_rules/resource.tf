@@ -0,0 +1,24 @@
+
+locals {
+  # an exception register, e.g. decoded from a CSV with one accepted finding per row.
+  exceptions = csvdecode(file("${path.module}/exceptions.csv"))
+}
+
+data "tenablesc_repository" "main" {
+  name = "main"
+}
+
+resource "tenablesc_accept_risk_rules" "exceptions" {
+  repository_id = data.tenablesc_repository.main.id
+
+  dynamic "rule" {
+    for_each = local.exceptions
+    content {
+      plugin_id  = rule.value.plugin_id
+      host_type  = "ip"
+      host_value = rule.value.hosts
+      expiration = rule.value.expiration
+      comments   = rule.value.ticket
+    }
+  }
+}
@@ -0,0 +1,23 @@
+data "tenablesc_repository" "agents" {
+  name = "Agents"
+}
+
+data "tenablesc_agent_groups" "workstations" {
+  nessus_manager_id = "3"
+  name_filter       = "workstations-.*"
+}
+
+resource "tenablesc_agent_scan" "workstations" {
+  name = "Nightly Workstation Agent Scan"
+
+  repository_id     = data.tenablesc_repository.agents.id
+  nessus_manager_id = "3"
+  agent_group_ids   = keys(data.tenablesc_agent_groups.workstations.agent_groups)
+
+  # Agents check in on their own schedule; give them a few hours to report back.
+  scan_window     = 240
+  email_on_finish = true
+
+  schedule_repeat_rule = "FREQ=DAILY;INTERVAL=1"
+  schedule_start       = "TZID=America/New_York:20230101T010000"
+}
@@ -1,14 +1,62 @@
-resource "tenablesc_asset" "static" {
-  name        = "Crash When Scanned"
-  description = "Asset to use to avoid scanning devices with easily hurt feelings."
-  # Now, the best way to use this asset is to generate dynamic assets that exclude this asset and scan with those.
-  # Unfortunately we haven't taught the TF provider how to handle generating those, so that would be a
-  # manual creation as of now.
-
-  type = "static"
-
-  values = [
-    "10.1.1.1",
-    "192.168.1.1",
-  ]
-}
\ No newline at end of file
+resource "tenablesc_asset" "static" {
+  name        = "Crash When Scanned"
+  description = "Asset to use to avoid scanning devices with easily hurt feelings."
+  # The best way to use this asset is to combine it with others and scan those, as below.
+
+  type = "static"
+
+  values = [
+    "10.1.1.1",
+    "192.168.1.1",
+  ]
+}
+
+resource "tenablesc_asset" "log4j" {
+  name = "Vulnerable log4j"
+  type = "dynamic"
+
+  rules {
+    operator = "all"
+
+    # https://www.tenable.com/plugins/nessus/156032
+    clause {
+      filter_name = "pluginText"
+      operator    = "pcre"
+      value       = "log4j-core-2\\.1[0-6]"
+      plugin_id   = "156032"
+    }
+
+    group {
+      operator = "any"
+
+      clause {
+        filter_name = "ip"
+        operator    = "="
+        value       = "10.0.0.0/8"
+      }
+      clause {
+        filter_name = "dns"
+        operator    = "contains"
+        value       = "corp.example.com"
+      }
+    }
+  }
+}
+
+resource "tenablesc_asset" "safe_to_scan" {
+  name = "Vulnerable log4j, safe to scan"
+  type = "combination"
+
+  combination = "${tenablesc_asset.log4j.id} AND NOT ${tenablesc_asset.static.id}"
+}
+
+resource "tenablesc_asset" "domain_controllers" {
+  name = "Domain Controllers"
+  type = "ldapquery"
+
+  ldap_query {
+    ldap_id       = "1"
+    search_base   = "OU=Domain Controllers,DC=corp,DC=example,DC=com"
+    search_string = "name=*"
+  }
+}
@@ -0,0 +1,37 @@
+variable "scanner_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "tenablesc_credential" "linux" {
+  name        = "Linux Scanner"
+  description = "Authenticated scans of linux hosts."
+
+  ssh {
+    auth_type            = "publicKey"
+    username             = "nessus"
+    private_key          = file("${path.module}/nessus_ed25519")
+    privilege_escalation = "sudo"
+  }
+}
+
+resource "tenablesc_credential" "windows" {
+  name = "Windows Scanner"
+
+  windows {
+    username = "svc-nessus"
+    password = var.scanner_password
+    domain   = "CORP"
+  }
+}
+
+resource "tenablesc_scan" "authenticated" {
+  name          = "Authenticated Scan"
+  repository_id = data.tenablesc_repository.lab.id
+  policy_id     = tenablesc_scan_policy.advanced.id
+
+  credential_ids = [
+    tenablesc_credential.linux.id,
+    tenablesc_credential.windows.id,
+  ]
+}
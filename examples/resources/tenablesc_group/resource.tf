@@ -0,0 +1,21 @@
+data "tenablesc_repository" "main" {
+  name = "main"
+}
+
+resource "tenablesc_asset" "soc" {
+  name = "SOC Hosts"
+  type = "static"
+  values = [
+    "10.0.0.0/24",
+  ]
+}
+
+resource "tenablesc_group" "soc" {
+  name = "SOC Analysts"
+
+  defining_asset_ids = [tenablesc_asset.soc.id]
+  repository_ids     = [data.tenablesc_repository.main.id]
+
+  share_assets   = true
+  share_policies = true
+}
@@ -0,0 +1,26 @@
+
+data "tenablesc_repository" "main" {
+  name = "main"
+}
+
+resource "tenablesc_recast_risk_rules" "main" {
+  repository_id = data.tenablesc_repository.main.id
+
+  rule {
+    # https://www.tenable.com/plugins/nessus/157288
+    plugin_id    = "157288"
+    new_severity = "4"
+    comments     = "TLS 1.1 is banned"
+  }
+
+  rule {
+    # https://www.tenable.com/plugins/nessus/51192
+    plugin_id    = "51192"
+    new_severity = "1"
+    host_type    = "ip"
+    host_value   = "10.0.0.0/24"
+    port         = "8443"
+    protocol     = "tcp"
+    comments     = "lab network certificates are self-signed"
+  }
+}
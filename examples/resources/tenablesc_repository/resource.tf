@@ -1,18 +1,31 @@
-resource "tenablesc_repository" "lab" {
-  name     = "Lab"
-  ip_range = "0.0.0.0/0"
-}
-
-data "tenablesc_organization" "lab" {
-  name = "Lab"
-}
-
-resource "tenablesc_repository_organization_association" "lab" {
-  repository_id = tenablesc_repository.lab.id
-  organization = [
-    {
-      organization_id = data.tenablesc_organization.lab.id
-      # group_assignment = "all" || "fullAccess" || "partial"
-    }
-  ]
-}
\ No newline at end of file
+resource "tenablesc_repository" "lab" {
+  name     = "Lab"
+  ip_range = "0.0.0.0/0"
+}
+
+data "tenablesc_organization" "lab" {
+  name = "Lab"
+}
+
+resource "tenablesc_repository_organization_association" "lab" {
+  repository_id = tenablesc_repository.lab.id
+  organization = [
+    {
+      organization_id = data.tenablesc_organization.lab.id
+      # group_assignment = "all" || "fullAccess" || "partial"
+    }
+  ]
+}
+
+resource "tenablesc_repository" "agents" {
+  name        = "Agents"
+  data_format = "agent"
+}
+
+resource "tenablesc_repository" "upstream" {
+  name        = "Upstream"
+  type        = "Remote"
+  remote_host = "sc.example.com"
+  # ID of the repository on sc.example.com
+  remote_repository_id = "4"
+}
@@ -20,7 +20,7 @@ resource "tenablesc_scan" "basic" {
   repository_id = data.tenablesc_repository.lab.id
   policy_id     = data.tenablesc_scan_policy.basic.id
 
-  asset_ids      = [data.tenablesc_asset.lab]
+  asset_ids      = [data.tenablesc_asset.lab.id]
   credential_ids = [data.tenablesc_credential.lab.id]
 
   # easiest way to determine what this should look like is to
@@ -0,0 +1,27 @@
+resource "tenablesc_asset" "new_hosts" {
+  name   = "New Hosts"
+  type   = "static"
+  values = aws_instance.app[*].private_ip
+}
+
+resource "tenablesc_scan" "validation" {
+  name          = "New Host Validation"
+  repository_id = data.tenablesc_repository.lab.id
+  policy_id     = tenablesc_scan_policy.advanced.id
+  asset_ids     = [tenablesc_asset.new_hosts.id]
+}
+
+resource "tenablesc_scan_launch" "validation" {
+  scan_id = tenablesc_scan.validation.id
+
+  # Scan again whenever the instances are replaced.
+  triggers = {
+    instances = join(",", aws_instance.app[*].id)
+  }
+
+  fail_on_error = true
+
+  timeouts {
+    create = "3h"
+  }
+}
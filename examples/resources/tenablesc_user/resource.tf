@@ -0,0 +1,29 @@
+variable "analyst_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "tenablesc_user" "analyst" {
+  username  = "jdoe"
+  auth_type = "password"
+  password  = var.analyst_password
+
+  role_id  = "3"
+  group_id = "0"
+
+  first_name = "Jane"
+  last_name  = "Doe"
+  email      = "jdoe@example.com"
+}
+
+resource "tenablesc_user" "ldap_auditor" {
+  username      = "auditor"
+  auth_type     = "ldap"
+  ldap_id       = "1"
+  ldap_username = "auditor@example.com"
+
+  role_id  = "5"
+  group_id = "0"
+
+  locked = true
+}
@@ -0,0 +1,442 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakesc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// endpoint describes how a single SC collection differs from plain CRUD-over-JSON.
+type endpoint struct {
+	name        string
+	displayName string
+
+	// usableManageable renders list responses in the org-scoped {usable, manageable} envelope
+	// instead of a bare array.
+	usableManageable bool
+	// createReturnsList renders the POST response as a single element array, as risk rules do.
+	createReturnsList bool
+
+	// defaults are applied to newly created objects before the request body.
+	defaults Object
+	// normalize translates a request body into the shape SC stores and returns.
+	normalize func(in Object) Object
+	// cleanup runs after a request has been merged into the stored object.
+	cleanup func(obj Object)
+	// validate may reject an object before it is stored.
+	validate func(s *Server, obj Object) error
+	// writeOnly are fields, top-level or in typeFields, that are stored but never rendered in responses, like secrets.
+	writeOnly []string
+	// onRead runs before an object is rendered for GET /<endpoint>/<id>.
+	onRead func(obj Object)
+	// actions handle POST /<endpoint>/<id>/<action>.
+	actions map[string]func(s *Server, w http.ResponseWriter, r *http.Request, obj Object)
+}
+
+// render returns obj as it appears in responses.
+func (ep *endpoint) render(obj Object) Object {
+	if len(ep.writeOnly) == 0 {
+		return obj
+	}
+
+	out := copyObject(obj)
+	for _, f := range ep.writeOnly {
+		delete(out, f)
+	}
+	if typeFields, ok := out["typeFields"].(map[string]interface{}); ok {
+		for _, f := range ep.writeOnly {
+			delete(typeFields, f)
+		}
+	}
+	return out
+}
+
+// repoBaseFields are the repository fields SC returns at the top level; everything else lives in typeFields.
+var repoBaseFields = map[string]bool{
+	"id":             true,
+	"name":           true,
+	"description":    true,
+	"dataFormat":     true,
+	"type":           true,
+	"downloadFormat": true,
+	"createdTime":    true,
+	"modifiedTime":   true,
+	"running":        true,
+	"organizations":  true,
+}
+
+func defaultEndpoints() map[string]*endpoint {
+	endpoints := []*endpoint{
+		{
+			name:             EndpointAgentScan,
+			displayName:      "Agent Scan",
+			usableManageable: true,
+			defaults: Object{
+				"status":   "0",
+				"schedule": Object{"type": "template", "enabled": "true"},
+			},
+		},
+		{
+			name:             EndpointAsset,
+			displayName:      "Asset",
+			usableManageable: true,
+			defaults:         Object{"prepare": "false", "ipCount": "-1"},
+			normalize:        moveToTypeFields("definedIPs", "definedDNSNames", "rules", "combinations", "definedLDAPQuery", "ldap"),
+		},
+		{
+			name:        EndpointRepository,
+			displayName: "Repository",
+			defaults:    Object{"running": "false", "organizations": []interface{}{}},
+			normalize: func(in Object) Object {
+				out := Object{}
+				typeFields := Object{}
+				for k, v := range in {
+					if repoBaseFields[k] {
+						out[k] = v
+					} else {
+						typeFields[k] = v
+					}
+				}
+				if len(typeFields) > 0 {
+					out["typeFields"] = typeFields
+				}
+				return out
+			},
+		},
+		{
+			name:             EndpointCredential,
+			displayName:      "Credential",
+			usableManageable: true,
+			defaults:         Object{"tags": "", "canUse": "true", "canManage": "true"},
+			normalize:        moveAllToTypeFields("id", "name", "description", "type", "tags", "canUse", "canManage"),
+			writeOnly:        []string{"password", "privateKey", "publicKey", "passphrase", "escalationPassword", "communityString"},
+		},
+		{
+			name:        EndpointGroup,
+			displayName: "Group",
+			defaults: Object{
+				"definingAssets": []interface{}{},
+				"repositories":   []interface{}{},
+				"createUser":     "false",
+				"assets":         "false",
+				"policies":       "false",
+			},
+		},
+		{
+			name:        EndpointOrganization,
+			displayName: "Organization",
+			defaults:    Object{"zones": []interface{}{}, "userCount": "0"},
+		},
+		{
+			name:        EndpointZone,
+			displayName: "Scan Zone",
+			defaults:    Object{"scanners": []interface{}{}, "organizations": []interface{}{}},
+		},
+		{
+			name:             EndpointScan,
+			displayName:      "Scan",
+			usableManageable: true,
+			defaults: Object{
+				"status":   "0",
+				"schedule": Object{"type": "template", "enabled": "true"},
+			},
+			actions: map[string]func(s *Server, w http.ResponseWriter, r *http.Request, obj Object){
+				"launch": launchScan,
+			},
+		},
+		{
+			name:             EndpointScanResult,
+			displayName:      "Scan Result",
+			usableManageable: true,
+			onRead:           advanceScanResult,
+			writeOnly:        []string{"outcomeStatus", "outcomeErrorDetails"},
+			actions: map[string]func(s *Server, w http.ResponseWriter, r *http.Request, obj Object){
+				"stop": func(s *Server, w http.ResponseWriter, r *http.Request, obj Object) {
+					obj["status"] = "Stopped"
+					writeResponse(w, nil)
+				},
+			},
+		},
+		{
+			name:             EndpointPolicy,
+			displayName:      "Policy",
+			usableManageable: true,
+			defaults:         Object{"preferences": Object{}, "families": []interface{}{}, "auditFiles": []interface{}{}},
+			cleanup: func(obj Object) {
+				removePrefs, _ := obj["removePrefs"].([]interface{})
+				prefs, _ := obj["preferences"].(map[string]interface{})
+				for _, p := range removePrefs {
+					if name, ok := p.(string); ok {
+						delete(prefs, name)
+					}
+				}
+				delete(obj, "removePrefs")
+				delete(obj, "context")
+			},
+		},
+		{
+			name:        EndpointRole,
+			displayName: "Role",
+		},
+		{
+			name:              EndpointAcceptRiskRule,
+			displayName:       "Accept Risk Rule",
+			createReturnsList: true,
+			defaults:          Object{"expires": "-1", "status": "0"},
+			normalize:         normalizeRiskRule,
+		},
+		{
+			name:              EndpointRecastRiskRule,
+			displayName:       "Recast Risk Rule",
+			createReturnsList: true,
+			defaults:          Object{"expires": "-1", "status": "0"},
+			normalize: func(in Object) Object {
+				out := normalizeRiskRule(in)
+				// Requests send the new severity as an {id} reference, responses render it as a bare string.
+				if sev, ok := out["newSeverity"].(map[string]interface{}); ok {
+					out["newSeverity"] = sev["id"]
+				}
+				return out
+			},
+		},
+		{
+			name:        EndpointUser,
+			displayName: "User",
+			defaults: Object{
+				"locked":           "false",
+				"responsibleAsset": Object{"id": "-1"},
+			},
+			normalize: referenceIDs(map[string]string{
+				"roleID":             "role",
+				"groupID":            "group",
+				"orgID":              "organization",
+				"responsibleAssetID": "responsibleAsset",
+				"ldapID":             "ldap",
+			}),
+			writeOnly: []string{"password"},
+		},
+		{
+			name:             EndpointAuditFile,
+			displayName:      "Audit File",
+			usableManageable: true,
+			defaults:         Object{"type": "", "status": "0"},
+			normalize:        moveToTypeFields("variables"),
+			validate: func(s *Server, obj Object) error {
+				filename, _ := obj["filename"].(string)
+				if _, ok := s.files[filename]; !ok {
+					return fmt.Errorf("Audit File filename '%s' has not been uploaded", filename)
+				}
+				return nil
+			},
+		},
+	}
+
+	m := make(map[string]*endpoint, len(endpoints))
+	for _, ep := range endpoints {
+		m[ep.name] = ep
+	}
+	return m
+}
+
+// launchScan queues a scan result for obj, which finishes with the server's scan outcome.
+func launchScan(s *Server, w http.ResponseWriter, r *http.Request, obj Object) {
+	id := s.newID()
+	result := Object{
+		"id":                  id,
+		"name":                obj["name"],
+		"status":              "Queued",
+		"scan":                Object{"id": obj["id"], "name": obj["name"]},
+		"totalIPs":            "4",
+		"scannedIPs":          "0",
+		"scanDuration":        "-1",
+		"errorDetails":        "",
+		"outcomeStatus":       s.scanOutcome[0],
+		"outcomeErrorDetails": s.scanOutcome[1],
+	}
+	s.collection(EndpointScanResult)[id] = result
+
+	writeResponse(w, Object{
+		"scanID":     obj["id"],
+		"scanResult": Object{"id": id, "scanID": obj["id"], "status": "Queued"},
+	})
+}
+
+// advanceScanResult moves a launched scan one step from Queued through Running to its outcome.
+func advanceScanResult(obj Object) {
+	switch obj["status"] {
+	case "Queued":
+		obj["status"] = "Running"
+	case "Running":
+		obj["status"] = obj["outcomeStatus"]
+		obj["errorDetails"] = obj["outcomeErrorDetails"]
+		obj["scanDuration"] = "42"
+		obj["scannedIPs"] = "4"
+		if obj["status"] != "Completed" {
+			obj["scannedIPs"] = "2"
+		}
+	}
+}
+
+// moveToTypeFields returns a normalizer moving the named request fields into the typeFields
+// sub-object SC uses in responses.
+func moveToTypeFields(fields ...string) func(Object) Object {
+	return func(in Object) Object {
+		typeFields := Object{}
+		for _, f := range fields {
+			if v, ok := in[f]; ok {
+				typeFields[f] = v
+				delete(in, f)
+			}
+		}
+		if len(typeFields) > 0 {
+			in["typeFields"] = typeFields
+		}
+		return in
+	}
+}
+
+// moveAllToTypeFields returns a normalizer moving every request field except the named base fields
+// into the typeFields sub-object SC uses in responses.
+func moveAllToTypeFields(baseFields ...string) func(Object) Object {
+	base := make(map[string]bool, len(baseFields))
+	for _, f := range baseFields {
+		base[f] = true
+	}
+
+	return func(in Object) Object {
+		var fields []string
+		for k := range in {
+			if !base[k] {
+				fields = append(fields, k)
+			}
+		}
+		return moveToTypeFields(fields...)(in)
+	}
+}
+
+// referenceIDs returns a normalizer translating request ID fields into the {id} objects SC
+// embeds in responses, as mapped from request field to response field.
+func referenceIDs(fields map[string]string) func(Object) Object {
+	return func(in Object) Object {
+		for from, to := range fields {
+			if v, ok := in[from]; ok {
+				in[to] = Object{"id": v}
+				delete(in, from)
+			}
+		}
+		return in
+	}
+}
+
+// normalizeRiskRule handles risk rules taking a list of repositories but only ever returning one.
+func normalizeRiskRule(in Object) Object {
+	if repos, ok := in["repositories"].([]interface{}); ok {
+		if len(repos) > 0 {
+			in["repository"] = repos[0]
+		}
+		delete(in, "repositories")
+	}
+	return in
+}
+
+func (s *Server) handleFile(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 1 || r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, ErrorCodeInvalidInput, fmt.Sprintf("Unsupported request %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	switch parts[0] {
+	case "upload":
+		file, header, err := r.FormFile("Filedata")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, fmt.Sprintf("No file uploaded: %s", err))
+			return
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+
+		filename := fmt.Sprintf("upload%s", s.newID())
+		s.files[filename] = string(content)
+
+		writeResponse(w, Object{
+			"filename":         filename,
+			"originalFilename": header.Filename,
+		})
+	case "clear":
+		in, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+		filename, _ := in["filename"].(string)
+		if _, ok := s.files[filename]; !ok {
+			writeError(w, http.StatusForbidden, ErrorCodeNotFound, fmt.Sprintf("File '%s' not found", filename))
+			return
+		}
+		delete(s.files, filename)
+		writeResponse(w, "")
+	default:
+		writeError(w, http.StatusNotFound, ErrorCodeInvalidInput, fmt.Sprintf("Invalid resource 'file/%s'", parts[0]))
+	}
+}
+
+func (s *Server) handleAnalysis(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, ErrorCodeInvalidInput, fmt.Sprintf("Unsupported request %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	in, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	s.analyses = append(s.analyses, in)
+
+	query, _ := in["query"].(map[string]interface{})
+	tool, _ := query["tool"].(string)
+	results := s.analysisResults[tool]
+
+	start, _ := strconv.Atoi(fmt.Sprint(in["startOffset"]))
+	end, err := strconv.Atoi(fmt.Sprint(in["endOffset"]))
+	if err != nil || end > len(results) {
+		end = len(results)
+	}
+	if start > end {
+		start = end
+	}
+	page := results[start:end]
+	if page == nil {
+		page = []Object{}
+	}
+
+	writeResponse(w, Object{
+		"totalRecords":    strconv.Itoa(len(results)),
+		"returnedRecords": len(page),
+		"startOffset":     strconv.Itoa(start),
+		"endOffset":       strconv.Itoa(end),
+		"results":         page,
+	})
+}
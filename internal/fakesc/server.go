@@ -0,0 +1,802 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakesc is an in-process fake of the Tenable.SC REST API.
+//
+// It is intentionally dumb: objects are stored as the JSON maps SC would return, requests are
+// translated into that shape per endpoint, and every response is wrapped in the same envelope
+// SC uses. It is good enough to drive full create/read/update/import/destroy cycles of the
+// provider without a live SC instance, and nothing more.
+package fakesc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAccessKey and DefaultSecretKey are the only API keys the server accepts unless changed with SetAPIKey.
+	DefaultAccessKey = "fake-access-key"
+	DefaultSecretKey = "fake-secret-key"
+
+	// DefaultUsername and DefaultPassword are the only login credentials the server accepts unless changed with SetLogin.
+	DefaultUsername = "terraform"
+	DefaultPassword = "fake-password"
+
+	// SessionCookie is the cookie a login sets, which must accompany the session token.
+	SessionCookie = "TNS_SESSIONID"
+
+	// APIPrefix is the path the API is served from, matching a real SC's /rest.
+	APIPrefix = "/rest"
+)
+
+// Error codes emitted in the SCResponse body alongside an HTTP 403, mirroring what SC sends.
+const (
+	ErrorCodeUnauthorized = 74
+	ErrorCodeNotFound     = 146
+	ErrorCodeInvalidInput = 143
+)
+
+// Scopes of the users API keys added with AddAPIKey belong to.
+const (
+	ScopeAdmin        = "admin"
+	ScopeOrganization = "organization"
+)
+
+// Endpoint names the fake knows about, relative to APIPrefix.
+const (
+	EndpointAcceptRiskRule = "acceptRiskRule"
+	EndpointAgentScan      = "agentScan"
+	EndpointAsset          = "asset"
+	EndpointAuditFile      = "auditFile"
+	EndpointCredential     = "credential"
+	EndpointGroup          = "group"
+	EndpointOrganization   = "organization"
+	EndpointPolicy         = "policy"
+	EndpointRecastRiskRule = "recastRiskRule"
+	EndpointRepository     = "repository"
+	EndpointRole           = "role"
+	EndpointScan           = "scan"
+	EndpointScanResult     = "scanResult"
+	EndpointUser           = "user"
+	EndpointZone           = "zone"
+)
+
+// adminEndpoints can only be written to by administrators; organization users may still read them.
+var adminEndpoints = map[string]bool{
+	EndpointOrganization: true,
+	EndpointRepository:   true,
+	EndpointZone:         true,
+}
+
+// organizationEndpoints can't be used by administrators at all.
+var organizationEndpoints = map[string]bool{
+	EndpointAcceptRiskRule: true,
+	EndpointAgentScan:      true,
+	EndpointAsset:          true,
+	EndpointCredential:     true,
+	EndpointGroup:          true,
+	EndpointPolicy:         true,
+	EndpointRecastRiskRule: true,
+	EndpointRole:           true,
+	EndpointScan:           true,
+	EndpointScanResult:     true,
+	"agentGroup":           true,
+	"analysis":             true,
+}
+
+// AgentGroupsEndpoint returns the endpoint to Seed the agent groups of a Nessus Manager with.
+func AgentGroupsEndpoint(managerID string) string {
+	return fmt.Sprintf("agentGroup/%s/remote", managerID)
+}
+
+// Object is a single SC object as it would be rendered in a response.
+type Object = map[string]interface{}
+
+// Server is a fake Tenable.SC instance backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	accessKey   string
+	secretKey   string
+	username    string
+	password    string
+	nextID      int
+	objects     map[string]map[string]Object
+	files       map[string]string
+	currentUser Object
+	requests    []string
+	endpoints   map[string]*endpoint
+
+	// scopedKeys are the keys added with AddAPIKey, by x-apikey header, and the users they belong to.
+	scopedKeys map[string]Object
+
+	// scanOutcome is the status and error details launched scans finish with.
+	scanOutcome [2]string
+
+	// failures are served, in order, instead of handling the next requests.
+	failures []failure
+
+	// latency delays every response, as an overloaded SC would.
+	latency time.Duration
+
+	// writes counts the writes in progress per endpoint, and maxWrites the most there were at once;
+	// the empty endpoint counts writes to any.
+	writes    map[string]int
+	maxWrites map[string]int
+
+	// sessions maps the tokens of open login sessions to their session cookie.
+	sessions map[string]string
+
+	// analysisResults are the results /analysis returns per tool, and analyses the queries it received.
+	analysisResults map[string][]Object
+	analyses        []Object
+}
+
+// NewServer starts a fake SC. Callers must Close it when done.
+func NewServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(s.handler())
+
+	return s
+}
+
+// NewTLSServer starts a fake SC serving HTTPS with httptest's self-signed certificate, see CACertPEM.
+// If clientCAs is not nil, clients must present a certificate signed by one of them.
+// Callers must Close it when done.
+func NewTLSServer(clientCAs *x509.CertPool) *Server {
+	s := newServer()
+	s.Server = httptest.NewUnstartedServer(s.handler())
+	if clientCAs != nil {
+		s.Server.TLS = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+		}
+	}
+	s.Server.StartTLS()
+
+	return s
+}
+
+// CACertPEM returns the PEM encoded certificate a server started with NewTLSServer can be verified with.
+func (s *Server) CACertPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+}
+
+func newServer() *Server {
+	s := &Server{
+		accessKey:   DefaultAccessKey,
+		secretKey:   DefaultSecretKey,
+		username:    DefaultUsername,
+		password:    DefaultPassword,
+		nextID:      1000,
+		scanOutcome: [2]string{"Completed", ""},
+		objects:     make(map[string]map[string]Object),
+		files:       make(map[string]string),
+		sessions:    make(map[string]string),
+		scopedKeys:  make(map[string]Object),
+		writes:      make(map[string]int),
+		maxWrites:   make(map[string]int),
+
+		analysisResults: make(map[string][]Object),
+		currentUser: Object{
+			"id":       "1",
+			"username": "terraform",
+			"authType": "tns",
+			"status":   "0",
+			"orgName":  "Fake Organization",
+			"organization": Object{
+				"id":   "1",
+				"name": "Fake Organization",
+			},
+			"role": Object{
+				"id":   "1",
+				"name": "Security Manager",
+			},
+		},
+	}
+	s.endpoints = defaultEndpoints()
+
+	return s
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(APIPrefix+"/", s.handle)
+	return mux
+}
+
+// URI returns the value to configure the provider's uri attribute with.
+func (s *Server) URI() string {
+	return s.URL + APIPrefix
+}
+
+// SetAPIKey changes the API keys the server will accept.
+func (s *Server) SetAPIKey(access, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessKey = access
+	s.secretKey = secret
+}
+
+// AddAPIKey adds an API key pair belonging to user, as /currentUser renders it. Unlike the default
+// key, which may do anything, requests with it are limited to what SC allows the user's scope:
+// administrators, whose organization id is 0, can't use organization objects, and organization users
+// can't modify admin objects like organizations, repositories and scan zones.
+func (s *Server) AddAPIKey(access, secret string, user Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scopedKeys[apiKeyHeader(access, secret)] = copyObject(user)
+}
+
+// SetLogin changes the username and password the server will accept at /token.
+func (s *Server) SetLogin(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.username = username
+	s.password = password
+}
+
+// Sessions returns the number of login sessions that have not been logged out.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+type failure struct {
+	status     int
+	code       int
+	message    string
+	retryAfter string
+	// method, if not empty, limits the failure to requests of that method.
+	method string
+}
+
+// FailNext makes the next count requests fail with an HTTP status and SC error, as a busy SC would.
+// If retryAfter is not empty it is sent as the Retry-After header.
+func (s *Server) FailNext(count, status, code int, message, retryAfter string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < count; i++ {
+		s.failures = append(s.failures, failure{status: status, code: code, message: message, retryAfter: retryAfter})
+	}
+}
+
+// FailNextRequest makes the next request of method fail with an HTTP status and SC error,
+// letting requests of other methods through.
+func (s *Server) FailNextRequest(method string, status, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{status: status, code: code, message: message, method: method})
+}
+
+// MaxConcurrentWrites returns the most requests other than GETs the server handled at once for endpoint,
+// like "repository", or for any endpoint if it is empty.
+func (s *Server) MaxConcurrentWrites(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.maxWrites[endpoint]
+}
+
+func (s *Server) trackWrite(endpoint string, delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range []string{endpoint, ""} {
+		s.writes[e] += delta
+		if s.writes[e] > s.maxWrites[e] {
+			s.maxWrites[e] = s.writes[e]
+		}
+	}
+}
+
+// SetLatency delays every response by latency, unless the client gives up on the request first.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = latency
+}
+
+// Seed stores obj as if it had been created through the API and returns its ID.
+// Useful for objects the provider only looks up and for simulating out-of-band changes.
+func (s *Server) Seed(endpointName string, obj Object) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	stored := copyObject(obj)
+	stored["id"] = id
+	s.collection(endpointName)[id] = stored
+
+	return id
+}
+
+// Get returns a copy of the stored object, or nil if it does not exist.
+func (s *Server) Get(endpointName, id string) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.collection(endpointName)[id]
+	if !ok {
+		return nil
+	}
+	return copyObject(obj)
+}
+
+// SetScanOutcome sets the status and error details scans launched from now on finish with.
+// Launched scans advance one step from Queued through Running to the outcome each time their result is read.
+func (s *Server) SetScanOutcome(status, errorDetails string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scanOutcome = [2]string{status, errorDetails}
+}
+
+// SetAnalysisResults sets the results /analysis returns for tool, before paging.
+// Filters are not applied; tests check them with Analyses instead.
+func (s *Server) SetAnalysisResults(tool string, results []Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.analysisResults[tool] = results
+}
+
+// Analyses returns the analysis queries received so far.
+func (s *Server) Analyses() []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Object, 0, len(s.analyses))
+	for _, a := range s.analyses {
+		out = append(out, copyObject(a))
+	}
+	return out
+}
+
+// Update merges fields into a stored object, as an out-of-band edit in the SC UI would.
+func (s *Server) Update(endpointName, id string, fields Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obj, ok := s.collection(endpointName)[id]; ok {
+		mergeObject(obj, fields)
+	}
+}
+
+// Remove deletes a stored object, as an out-of-band delete in the SC UI would.
+func (s *Server) Remove(endpointName, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.collection(endpointName), id)
+}
+
+// Count returns the number of stored objects for an endpoint.
+func (s *Server) Count(endpointName string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.collection(endpointName))
+}
+
+// File returns the content of an uploaded file and whether it exists.
+func (s *Server) File(filename string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.files[filename]
+	return content, ok
+}
+
+// Requests returns the "METHOD /path" lines of every request served so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) collection(endpointName string) map[string]Object {
+	c, ok := s.objects[endpointName]
+	if !ok {
+		c = make(map[string]Object)
+		s.objects[endpointName] = c
+	}
+	return c
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, APIPrefix), "/")
+
+	if r.Method != http.MethodGet {
+		endpoint := strings.Split(path, "/")[0]
+		s.trackWrite(endpoint, 1)
+		defer s.trackWrite(endpoint, -1)
+	}
+
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, fmt.Sprintf("%s /%s", r.Method, path))
+
+	for i, f := range s.failures {
+		if f.method != "" && f.method != r.Method {
+			continue
+		}
+		s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+		if f.retryAfter != "" {
+			w.Header().Set("Retry-After", f.retryAfter)
+		}
+		writeError(w, f.status, f.code, f.message)
+		return
+	}
+
+	if path == "token" && r.Method == http.MethodPost {
+		s.login(w, r)
+		return
+	}
+
+	user, scope, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusForbidden, ErrorCodeUnauthorized, "Invalid login credentials")
+		return
+	}
+
+	parts := strings.Split(path, "/")
+
+	if (scope == ScopeAdmin && organizationEndpoints[parts[0]]) ||
+		(scope == ScopeOrganization && adminEndpoints[parts[0]] && r.Method != http.MethodGet) {
+		writeError(w, http.StatusForbidden, ErrorCodeUnauthorized, fmt.Sprintf("Insufficient permissions for %s", parts[0]))
+		return
+	}
+
+	switch parts[0] {
+	case "token":
+		if r.Method == http.MethodDelete {
+			delete(s.sessions, r.Header.Get("X-SecurityCenter"))
+			writeResponse(w, nil)
+			return
+		}
+	case "currentUser":
+		writeResponse(w, user)
+		return
+	case "file":
+		s.handleFile(w, r, parts[1:])
+		return
+	case "analysis":
+		s.handleAnalysis(w, r)
+		return
+	case "agentGroup":
+		if len(parts) == 3 && parts[2] == "remote" && r.Method == http.MethodGet {
+			s.list(w, &endpoint{name: AgentGroupsEndpoint(parts[1])})
+			return
+		}
+	}
+
+	ep, ok := s.endpoints[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrorCodeInvalidInput, fmt.Sprintf("Invalid resource '%s'", parts[0]))
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.list(w, ep)
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.create(w, r, ep)
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		s.read(w, ep, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodPatch:
+		s.patch(w, r, ep, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		s.delete(w, ep, parts[1])
+	case len(parts) == 3 && ep.actions != nil && ep.actions[parts[2]] != nil && r.Method == http.MethodPost:
+		obj, ok := s.collection(ep.name)[parts[1]]
+		if !ok {
+			writeNotFound(w, ep, parts[1])
+			return
+		}
+		ep.actions[parts[2]](s, w, r, obj)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, ErrorCodeInvalidInput, fmt.Sprintf("Unsupported request %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// authenticate returns the user a request is made as, and the scope it is limited to, if any.
+func (s *Server) authenticate(r *http.Request) (user Object, scope string, ok bool) {
+	if token := r.Header.Get("X-SecurityCenter"); token != "" {
+		cookie, err := r.Cookie(SessionCookie)
+		ok = err == nil && s.sessions[token] != "" && s.sessions[token] == cookie.Value
+		return s.currentUser, "", ok
+	}
+
+	key := r.Header.Get("x-apikey")
+	if key == apiKeyHeader(s.accessKey, s.secretKey) {
+		return s.currentUser, "", true
+	}
+	if user, ok := s.scopedKeys[key]; ok {
+		scope := ScopeOrganization
+		if org, _ := user["organization"].(map[string]interface{}); org == nil || org["id"] == "0" || org["id"] == "" {
+			scope = ScopeAdmin
+		}
+		return user, scope, true
+	}
+	return nil, "", false
+}
+
+func apiKeyHeader(access, secret string) string {
+	return fmt.Sprintf("accesskey=%s; secretkey=%s;", access, secret)
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var creds struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	if creds.Username != s.username || creds.Password != s.password {
+		writeError(w, http.StatusForbidden, ErrorCodeUnauthorized, "Invalid login credentials")
+		return
+	}
+
+	// SC hands out numeric tokens.
+	token := s.newID()
+	cookie := "session-" + token
+	s.sessions[token] = cookie
+
+	http.SetCookie(w, &http.Cookie{Name: SessionCookie, Value: cookie, Path: "/", HttpOnly: true})
+	writeResponse(w, Object{"token": json.Number(token), "unassociatedCert": "false"})
+}
+
+func (s *Server) list(w http.ResponseWriter, ep *endpoint) {
+	c := s.collection(ep.name)
+
+	ids := make([]string, 0, len(c))
+	for id := range c {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+
+	objs := make([]Object, 0, len(ids))
+	for _, id := range ids {
+		objs = append(objs, ep.render(c[id]))
+	}
+
+	if ep.usableManageable {
+		writeResponse(w, Object{
+			"usable":     objs,
+			"manageable": objs,
+		})
+		return
+	}
+
+	writeResponse(w, objs)
+}
+
+func (s *Server) read(w http.ResponseWriter, ep *endpoint, id string) {
+	obj, ok := s.collection(ep.name)[id]
+	if !ok {
+		writeNotFound(w, ep, id)
+		return
+	}
+	if ep.onRead != nil {
+		ep.onRead(obj)
+	}
+	writeResponse(w, ep.render(obj))
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, ep *endpoint) {
+	in, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+
+	obj := Object{}
+	mergeObject(obj, ep.defaults)
+	if ep.normalize != nil {
+		in = ep.normalize(in)
+	}
+	mergeObject(obj, in)
+	if ep.cleanup != nil {
+		ep.cleanup(obj)
+	}
+	if ep.validate != nil {
+		if err := ep.validate(s, obj); err != nil {
+			writeError(w, http.StatusForbidden, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+	}
+
+	id := s.newID()
+	obj["id"] = id
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	obj["createdTime"] = now
+	obj["modifiedTime"] = now
+
+	s.collection(ep.name)[id] = obj
+
+	if ep.createReturnsList {
+		writeResponse(w, []Object{ep.render(obj)})
+		return
+	}
+	writeResponse(w, ep.render(obj))
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, ep *endpoint, id string) {
+	existing, ok := s.collection(ep.name)[id]
+	if !ok {
+		writeNotFound(w, ep, id)
+		return
+	}
+
+	in, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeInvalidInput, err.Error())
+		return
+	}
+	if ep.normalize != nil {
+		in = ep.normalize(in)
+	}
+	delete(in, "id")
+
+	obj := copyObject(existing)
+	mergeObject(obj, in)
+	if ep.cleanup != nil {
+		ep.cleanup(obj)
+	}
+	if ep.validate != nil {
+		if err := ep.validate(s, obj); err != nil {
+			writeError(w, http.StatusForbidden, ErrorCodeInvalidInput, err.Error())
+			return
+		}
+	}
+	obj["modifiedTime"] = strconv.FormatInt(time.Now().Unix(), 10)
+	s.collection(ep.name)[id] = obj
+
+	writeResponse(w, ep.render(obj))
+}
+
+func (s *Server) delete(w http.ResponseWriter, ep *endpoint, id string) {
+	c := s.collection(ep.name)
+	if _, ok := c[id]; !ok {
+		writeNotFound(w, ep, id)
+		return
+	}
+	delete(c, id)
+	writeResponse(w, "")
+}
+
+func decodeBody(r *http.Request) (Object, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read request body: %w", err)
+	}
+	in := Object{}
+	if len(body) == 0 {
+		return in, nil
+	}
+	if err := json.Unmarshal(body, &in); err != nil {
+		return nil, fmt.Errorf("invalid JSON in request body: %w", err)
+	}
+	return in, nil
+}
+
+// writeResponse wraps the payload in SC's standard response envelope.
+func writeResponse(w http.ResponseWriter, payload interface{}) {
+	writeEnvelope(w, http.StatusOK, payload, 0, "")
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeEnvelope(w, status, "", code, message)
+}
+
+func writeNotFound(w http.ResponseWriter, ep *endpoint, id string) {
+	writeError(w, http.StatusForbidden, ErrorCodeNotFound, fmt.Sprintf("%s #%s not found", ep.displayName, id))
+}
+
+func writeEnvelope(w http.ResponseWriter, status int, payload interface{}, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Object{
+		"type":       "regular",
+		"response":   payload,
+		"error_code": code,
+		"error_msg":  message,
+		"warnings":   []string{},
+		"timestamp":  time.Now().Unix(),
+	})
+}
+
+// mergeObject applies src on top of dst the way SC applies a PATCH: nested objects are merged,
+// anything else is replaced wholesale.
+func mergeObject(dst, src Object) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeObject(dstMap, srcMap)
+			continue
+		}
+		dst[k] = copyValue(v)
+	}
+}
+
+func copyObject(obj Object) Object {
+	return copyValue(obj).(map[string]interface{})
+}
+
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = copyValue(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = copyValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -15,38 +15,20 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"os"
 	"regexp"
-	"runtime"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 	"inet.af/netaddr"
 )
 
-// line-by-line logging for error discovery is far too interesting to not have loglines.
-// Including an entire log framework to get .Caller() type functions also seemed like overkill, so
-// welcome to the cheapest log formatting we could manage.
-
-func init() {
-	log.SetOutput(os.Stderr)
-}
-
-const (
-	logDebug = "DEBUG"
-	logTrace = "TRACE"
-	logError = "ERROR"
-	//logWarn  = "WARN"
-	logInfo = "INFO"
-)
-
 var RecastAcceptRiskProtocolIDMap = map[string]string{
 	"tcp":     "6",
 	"udp":     "17",
@@ -55,55 +37,66 @@ var RecastAcceptRiskProtocolIDMap = map[string]string{
 	"any":     "any",
 }
 
-// Intended for use by logging functions that want to identify where in code
-// things are happening.
-func trace(skip int) (string, int, string) {
-	// if called with 0, we still want the function it's called from... not skip itself.
-	pc, file, line, ok := runtime.Caller(skip + 1)
-	if !ok {
-		return "?", 0, "?"
-	}
-
-	fn := runtime.FuncForPC(pc)
-	if fn == nil {
-		return file, line, "?"
-	}
-
-	return file, line, fn.Name()
-}
-
-func Logf(level, message string, args ...interface{}) {
-	skipLogf(1, level, message, args...)
-}
-
-func skipLogf(skip int, level, message string, args ...interface{}) {
-	fileName, lineNumber, funcName := trace(skip + 1)
-
-	formattedMessage := fmt.Sprintf(message, args...)
-
-	for _, line := range strings.Split(formattedMessage, "\n") {
-		log.Printf("[%s] %s:%d in %s, %s\n", level, fileName, lineNumber, funcName, line)
-	}
-}
-
 // tenablesc client wraps errors that are for missing records in a custom struct.
 // We will consistently want to treat not-found as a nonerror case so we can
 // do basic drift handling and corrective plans.
-func handleNotFoundError(d *schema.ResourceData, err error) diag.Diagnostics {
+// Permission errors are not: dropping the resource from state would have the next apply recreate it.
+func handleNotFoundError(ctx context.Context, d *schema.ResourceData, err error) diag.Diagnostics {
 	if err != nil {
 		nfe := tenablesc.NotFoundError{}
 
 		if errors.As(err, &nfe) {
 			// Someone already deleted it, not an error.
 			d.SetId("")
-			skipLogf(2, logInfo, "Got NotFoundError response, assuming resource has been deleted.")
+			logInfo(ctx, "got NotFoundError response, assuming resource has been deleted")
 			return nil
 		}
+
+		if errors.As(err, &tenablesc.PermissionError{}) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("permission denied for SC object %s", d.Id()),
+				Detail:   fmt.Sprintf("SC refused access to the object, so it is kept in state. Check that the credentials are valid and have the role and organization, or administrator scope, this resource requires: %s", err),
+			}}
+		}
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
+// replaceRiskRule updates a risk rule, which SC can't change in place, by creating its replacement with
+// create and only then deleting it with remove, so the findings it covers never briefly lose their
+// acceptance or recast. State follows the replacement as soon as it exists; if deleting the rule it
+// replaced fails, both rules are left in SC and the error says so.
+func replaceRiskRule(ctx context.Context, d *schema.ResourceData, m interface{}, kind string, create func() (string, error), remove func(id string) error, read schema.ReadContextFunc) diag.Diagnostics {
+	oldID := d.Id()
+
+	newID, err := create()
+	if err != nil {
+		// keep the previous state, which still describes the rule in SC.
+		d.Partial(true)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("failed to create the %s rule replacing rule %s", kind, oldID),
+			Detail:   fmt.Sprintf("Rule %s is unchanged and still applies: %s", oldID, err),
+		}}
+	}
+
+	d.SetId(newID)
+	logInfo(ctx, "created replacement "+kind+" rule", map[string]interface{}{"replaced_id": oldID, logFieldObjectID: newID})
+
+	var diags diag.Diagnostics
+	if err := remove(oldID); err != nil && !errors.As(err, &tenablesc.NotFoundError{}) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s rule %s was replaced by rule %s, but could not be deleted", kind, oldID, newID),
+			Detail:   fmt.Sprintf("Both rules now apply in SC, and state tracks rule %s. Delete rule %s in SC: %s", newID, oldID, err),
+		})
+	}
+
+	return append(diags, read(ctx, d, m)...)
+}
+
 // DiffSuppressParsedTimes handles tenable's attempts to be incredibly helpful by
 //
 //	returning timestamps in local time instead of UTC.
@@ -137,23 +130,15 @@ func diffSuppressNormalizedIPSet(k, oldValue, newValue string, d *schema.Resourc
 
 	oldSet, err := buildIPSetForTenableFormat(oldValue)
 	if err != nil {
-		Logf(logDebug, err.Error())
 		return false
 	}
 
 	newSet, err := buildIPSetForTenableFormat(newValue)
 	if err != nil {
-		Logf(logDebug, err.Error())
 		return false
 	}
 
-	if oldSet.Equal(newSet) {
-		return true
-	}
-
-	Logf(logDebug, "not equivalent ipsets: oldSet=%v, newset=%v", oldSet, newSet)
-
-	return false
+	return oldSet.Equal(newSet)
 }
 
 // IPSets may start with quotes. instead of trying to get clever, just split on quotes too.
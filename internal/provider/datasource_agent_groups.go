@@ -0,0 +1,92 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+func DataSourceAgentGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAgentGroupsRead,
+		Description: descriptionDataSourceAgentGroups,
+		Schema: map[string]*schema.Schema{
+			"nessus_manager_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: descriptionNessusManagerID,
+			},
+			"agent_groups": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: fmt.Sprintf(descriptionMapIDToNameTemplate, "agent group", "agent group"),
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"name_filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     ".*",
+				Description: fmt.Sprintf(descriptionRegexpNameFilterTemplate, "agent group"),
+			},
+		},
+	}
+}
+
+func dataSourceAgentGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sc := m.(*tenablesc.Client)
+
+	managerID := d.Get("nessus_manager_id").(string)
+	nameFilter := d.Get("name_filter").(string)
+
+	logDebug(ctx, "looking up agent groups", map[string]interface{}{"nessus_manager_id": managerID})
+
+	if len(nameFilter) == 0 {
+		return diag.Errorf("filter is empty string, will return no entries.")
+	}
+
+	nameRE, err := regexp.Compile("^" + nameFilter + "$")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groups, err := sc.GetAgentGroupsForScanner(managerID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	agentGroups := make(map[string]interface{})
+	for _, group := range groups {
+		if nameRE.MatchString(group.Name) {
+			agentGroups[string(group.ID)] = group.Name
+		}
+	}
+
+	logDebug(ctx, "filtered result set", map[string]interface{}{"count": len(agentGroups)})
+
+	if len(agentGroups) == 0 {
+		return diag.Errorf("no agent groups on nessus manager %s matching filter '^%s$'", managerID, nameFilter)
+	}
+
+	d.SetId(fmt.Sprintf("agent_groups:%s:%s", managerID, nameFilter))
+	d.Set("agent_groups", agentGroups)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestDataSourceAgentGroups(t *testing.T) {
+	h := newTestHarness(t)
+
+	workstations := h.server.Seed(fakesc.AgentGroupsEndpoint("7"), fakesc.Object{"name": "workstations"})
+	h.server.Seed(fakesc.AgentGroupsEndpoint("7"), fakesc.Object{"name": "servers"})
+	h.server.Seed(fakesc.AgentGroupsEndpoint("8"), fakesc.Object{"name": "workstations-eu"})
+
+	state, diags := h.readDataSource("tenablesc_agent_groups", map[string]interface{}{
+		"nessus_manager_id": "7",
+		"name_filter":       "work.*",
+	})
+	requireNoErrors(t, "read agent groups", diags)
+	requireAttribute(t, state, "agent_groups.%", "1")
+	requireAttribute(t, state, "agent_groups."+workstations, "workstations")
+
+	_, diags = h.readDataSource("tenablesc_agent_groups", map[string]interface{}{
+		"nessus_manager_id": "7",
+		"name_filter":       "laptops",
+	})
+	if !diags.HasError() {
+		t.Fatal("expected an error when no agent groups match")
+	}
+}
@@ -21,7 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourceAsset() *schema.Resource {
@@ -51,10 +51,10 @@ func DataSourceAsset() *schema.Resource {
 }
 
 func dataSourceAssetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	sc := m.(*tenablesc.Client)
+	sc := m.(*tenablesc.Client).WithCachedLists()
 	assetName := d.Get("name").(string)
 
-	Logf(logDebug, "looking up %s", assetName)
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": assetName})
 
 	allAssets, err := sc.GetAllAssets()
 	if err != nil {
@@ -62,7 +62,7 @@ func dataSourceAssetRead(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	for _, asset := range allAssets {
-		Logf(logTrace, "comparing asset: %+v", *asset)
+		logTrace(ctx, "comparing asset", map[string]interface{}{logFieldObjectID: asset.ID, "name": asset.Name})
 		if strings.Compare(asset.Name, assetName) == 0 {
 			d.SetId(string(asset.ID))
 			d.Set("definedDNSNames", asset.DefinedDNSNames)
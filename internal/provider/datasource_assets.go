@@ -21,7 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourceAssets() *schema.Resource {
@@ -46,17 +46,15 @@ func DataSourceAssets() *schema.Resource {
 }
 
 func dataSourceAssetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	sc := m.(*tenablesc.Client)
+	sc := m.(*tenablesc.Client).WithCachedLists()
 
-	Logf(logDebug, "looking up all assets")
+	logDebug(ctx, "looking up all assets")
 
 	allAssets, err := sc.GetAllAssets()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	Logf(logDebug, "response: %+v", allAssets)
-
 	assets := make(map[string]interface{})
 
 	nameFilter := d.Get("name_filter").(string)
@@ -83,7 +81,7 @@ func dataSourceAssetsRead(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
-	Logf(logDebug, "Result set: %v", assets)
+	logDebug(ctx, "filtered result set", map[string]interface{}{"count": len(assets)})
 
 	if len(assets) == 0 {
 		return diag.Errorf("no assets matching filter '^%s$'", nameFilter)
@@ -0,0 +1,188 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// countRequests returns how many requests the server got for endpoint, like GET /asset.
+func countRequests(h *testHarness, request string) int {
+	count := 0
+	for _, r := range h.server.Requests() {
+		if r == request {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDataSourcesShareCachedLists(t *testing.T) {
+	h := newTestHarness(t)
+	h.server.Seed(fakesc.EndpointAsset, fakesc.Object{"name": "web", "type": "static"})
+	h.server.Seed(fakesc.EndpointAsset, fakesc.Object{"name": "db", "type": "static"})
+
+	for _, name := range []string{"web", "db"} {
+		_, diags := h.readDataSource("tenablesc_asset", map[string]interface{}{"name": name})
+		requireNoErrors(t, "read asset "+name, diags)
+	}
+	state, diags := h.readDataSource("tenablesc_assets", map[string]interface{}{})
+	requireNoErrors(t, "read assets", diags)
+	requireAttribute(t, state, "assets.%", "2")
+
+	if count := countRequests(h, "GET /asset"); count != 1 {
+		t.Fatalf("expected assets to be listed once, got %d requests", count)
+	}
+
+	// Writing an asset must not leave data sources with the stale list.
+	h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "mail",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.1"},
+	})
+	state, diags = h.readDataSource("tenablesc_assets", map[string]interface{}{})
+	requireNoErrors(t, "read assets", diags)
+	requireAttribute(t, state, "assets.%", "3")
+	if count := countRequests(h, "GET /asset"); count != 2 {
+		t.Fatalf("expected assets to be listed again after a write, got %d requests", count)
+	}
+}
+
+func TestCachedListsQueriedOnceConcurrently(t *testing.T) {
+	h := newTestHarness(t)
+	h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "local", "type": "Local", "dataFormat": "IPv4"})
+	h.server.SetLatency(50 * time.Millisecond)
+
+	sc := h.provider.Meta().(*providerClients).defaultClient.WithCachedLists()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos, err := sc.GetAllRepositories()
+			if err == nil && len(repos) != 1 {
+				err = fmt.Errorf("expected one repository, got %d", len(repos))
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count := countRequests(h, "GET /repository"); count != 1 {
+		t.Fatalf("expected concurrent lookups to share one request, got %d", count)
+	}
+}
+
+func TestCachedListsNotKeptAcrossWrites(t *testing.T) {
+	h := newTestHarness(t)
+	sc := h.provider.Meta().(*providerClients).defaultClient
+	groups, err := sc.GetAllGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h.server.SetLatency(50 * time.Millisecond)
+	listed := make(chan error)
+	go func() {
+		_, err := sc.WithCachedLists().GetAllGroups()
+		listed <- err
+	}()
+
+	// the group is created while the list is queried.
+	time.Sleep(10 * time.Millisecond)
+	h.server.SetLatency(0)
+	if _, err := sc.CreateGroup(&tenablesc.Group{BaseInfo: tenablesc.BaseInfo{Name: "analysts"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-listed; err != nil {
+		t.Fatal(err)
+	}
+
+	cached, err := sc.WithCachedLists().GetAllGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cached) != len(groups)+1 {
+		t.Fatalf("expected the group created to be listed, got %d groups", len(cached))
+	}
+	if count := countRequests(h, "GET /group"); count != 3 {
+		t.Fatalf("expected a list queried across a write to be queried again, got %d requests", count)
+	}
+}
+
+func TestCachedListsRequeriedWhenFirstCallerGivesUp(t *testing.T) {
+	h := newTestHarness(t)
+	h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "local", "type": "Local", "dataFormat": "IPv4"})
+	h.server.SetLatency(50 * time.Millisecond)
+
+	sc := h.provider.Meta().(*providerClients).defaultClient.WithCachedLists()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := make(chan error)
+	go func() {
+		_, err := sc.WithContext(ctx).GetAllRepositories()
+		first <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	second := make(chan error)
+	go func() {
+		repos, err := sc.GetAllRepositories()
+		if err == nil && len(repos) != 1 {
+			err = fmt.Errorf("expected one repository, got %d", len(repos))
+		}
+		second <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-first; err == nil {
+		t.Fatal("expected the cancelled lookup to fail")
+	}
+	if err := <-second; err != nil {
+		t.Fatalf("expected a lookup waiting on a cancelled one to query SC itself: %v", err)
+	}
+}
+
+func TestCachedListsCanBeDisabled(t *testing.T) {
+	h := newTestHarness(t)
+	sc := h.configureClient(map[string]interface{}{"cache_data_source_lists": false}).WithCachedLists()
+
+	for i := 0; i < 2; i++ {
+		if _, err := sc.GetAllRepositories(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count := countRequests(h, "GET /repository"); count != 2 {
+		t.Fatalf("expected every lookup to query SC with the cache disabled, got %d requests", count)
+	}
+}
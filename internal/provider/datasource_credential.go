@@ -21,7 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourceCredential() *schema.Resource {
@@ -39,10 +39,10 @@ func DataSourceCredential() *schema.Resource {
 }
 
 func dataSourceCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	sc := m.(*tenablesc.Client)
+	sc := m.(*tenablesc.Client).WithCachedLists()
 
 	credentialName := d.Get("name").(string)
-	Logf(logDebug, "looking up %s", credentialName)
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": credentialName})
 
 	credentialResponse, err := sc.GetAllCredentials()
 	if err != nil {
@@ -50,7 +50,7 @@ func dataSourceCredentialRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	for _, credential := range credentialResponse {
-		Logf(logTrace, "comparing credential %+v", *credential)
+		logTrace(ctx, "comparing credential", map[string]interface{}{logFieldObjectID: credential.ID, "name": credential.Name})
 		if strings.Compare(credential.Name, credentialName) == 0 {
 			d.SetId(string(credential.ID))
 			return nil
@@ -0,0 +1,81 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+func DataSourceGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGroupRead,
+		Description: descriptionDataSourceGroup,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: fmt.Sprintf(descriptionDataSourceNameFindTemplate, "group"),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionGroupDescription,
+			},
+			"defining_asset_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: descriptionGroupDefiningAssetIDs,
+			},
+			"repository_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: descriptionGroupRepositoryIDs,
+			},
+		},
+	}
+}
+
+func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sc := m.(*tenablesc.Client).WithCachedLists()
+
+	groupName := d.Get("name").(string)
+
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": groupName})
+
+	groups, err := sc.GetAllGroups()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, group := range groups {
+		logTrace(ctx, "comparing group", map[string]interface{}{logFieldObjectID: group.ID, "name": group.Name})
+		if group.Name == groupName {
+			d.SetId(string(group.ID))
+			d.Set("description", group.Description)
+			d.Set("defining_asset_ids", unbundleIDs(group.DefiningAssets))
+			d.Set("repository_ids", unbundleIDs(group.Repositories))
+			return nil
+		}
+	}
+
+	return diag.Errorf("No group with name [%s] found", groupName)
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestDataSourceGroup(t *testing.T) {
+	h := newTestHarness(t)
+
+	h.server.Seed(fakesc.EndpointGroup, fakesc.Object{"name": "Full Access", "description": "Full Access group"})
+	analysts := h.server.Seed(fakesc.EndpointGroup, fakesc.Object{
+		"name":           "analysts",
+		"description":    "SOC analysts",
+		"definingAssets": []interface{}{fakesc.Object{"id": "10", "name": "SOC"}},
+		"repositories":   []interface{}{fakesc.Object{"id": "1"}, fakesc.Object{"id": "2"}},
+	})
+
+	state, diags := h.readDataSource("tenablesc_group", map[string]interface{}{"name": "analysts"})
+	requireNoErrors(t, "read group", diags)
+	if state.ID != analysts {
+		t.Fatalf("expected group %s, got %s", analysts, state.ID)
+	}
+	requireAttribute(t, state, "description", "SOC analysts")
+	requireAttribute(t, state, "defining_asset_ids.#", "1")
+	requireAttribute(t, state, "repository_ids.#", "2")
+
+	if _, diags := h.readDataSource("tenablesc_group", map[string]interface{}{"name": "auditors"}); !diags.HasError() {
+		t.Fatal("expected an error when no group matches")
+	}
+}
@@ -19,7 +19,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourcePlugin() *schema.Resource {
@@ -39,7 +39,7 @@ func DataSourcePlugin() *schema.Resource {
 func dataSourcePluginRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pluginName := d.Get("name").(string)
 
-	Logf(logDebug, "looking up %s", pluginName)
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": pluginName})
 
 	sc := m.(*tenablesc.Client)
 
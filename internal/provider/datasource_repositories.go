@@ -21,7 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourceRepositories() *schema.Resource {
@@ -35,6 +35,18 @@ func DataSourceRepositories() *schema.Resource {
 				Description: fmt.Sprintf(descriptionMapIDToNameTemplate, "repository", "repository"),
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"types": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: descriptionRepositoryTypes,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"data_formats": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: descriptionRepositoryDataFormats,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"name_filter": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -46,18 +58,18 @@ func DataSourceRepositories() *schema.Resource {
 }
 
 func dataSourceRepositoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	sc := m.(*tenablesc.Client)
+	sc := m.(*tenablesc.Client).WithCachedLists()
 
-	Logf(logDebug, "looking up all repositories")
+	logDebug(ctx, "looking up all repositories")
 
 	repos, err := sc.GetAllRepositories()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	Logf(logDebug, "response: %+v", repos)
-
 	repositories := make(map[string]interface{})
+	types := make(map[string]interface{})
+	dataFormats := make(map[string]interface{})
 
 	nameFilter := d.Get("name_filter").(string)
 
@@ -80,16 +92,20 @@ func dataSourceRepositoriesRead(ctx context.Context, d *schema.ResourceData, m i
 	for _, repo := range repos {
 		if nameRE.MatchString(repo.Name) {
 			repositories[string(repo.ID)] = repo.Name
+			types[string(repo.ID)] = repo.Type
+			dataFormats[string(repo.ID)] = repo.DataFormat
 		}
 	}
 
-	Logf(logDebug, "Result set: %v", repositories)
+	logDebug(ctx, "filtered result set", map[string]interface{}{"count": len(repositories)})
 
 	if len(repos) == 0 {
 		return diag.Errorf("no repositories matching filter '^%s$'", nameFilter)
 	}
 
 	d.Set("repositories", repositories)
+	d.Set("types", types)
+	d.Set("data_formats", dataFormats)
 
 	return nil
 }
@@ -0,0 +1,65 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+// seedMixedRepositories seeds one repository of each of a modeled IP, a modeled agent and an unmodeled type.
+func seedMixedRepositories(h *testHarness) (local, remoteAgent, mobile string) {
+	local = h.server.Seed(fakesc.EndpointRepository, fakesc.Object{
+		"name": "local", "type": "Local", "dataFormat": "IPv4",
+		"typeFields": fakesc.Object{"ipRange": "10.0.0.0/8", "trendingDays": "30"},
+	})
+	remoteAgent = h.server.Seed(fakesc.EndpointRepository, fakesc.Object{
+		"name": "remote agents", "type": "Remote", "dataFormat": "agent",
+		"typeFields": fakesc.Object{"remoteID": "3", "remoteIP": "sc.example.com"},
+	})
+	mobile = h.server.Seed(fakesc.EndpointRepository, fakesc.Object{
+		"name": "mobile", "type": "MDM", "dataFormat": "mobile",
+		"typeFields": fakesc.Object{"mdm": fakesc.Object{"id": 1}, "trendingDays": 30},
+	})
+	return
+}
+
+func TestDataSourceRepositoriesToleratesUnmodeledTypes(t *testing.T) {
+	h := newTestHarness(t)
+	local, remoteAgent, mobile := seedMixedRepositories(h)
+
+	state, diags := h.readDataSource("tenablesc_repositories", map[string]interface{}{})
+	requireNoErrors(t, "read repositories", diags)
+	requireAttribute(t, state, "repositories.%", "3")
+	requireAttribute(t, state, "types."+local, "Local")
+	requireAttribute(t, state, "types."+remoteAgent, "Remote")
+	requireAttribute(t, state, "data_formats."+remoteAgent, "agent")
+	requireAttribute(t, state, "types."+mobile, "MDM")
+	requireAttribute(t, state, "data_formats."+mobile, "mobile")
+}
+
+func TestDataSourceRepository(t *testing.T) {
+	h := newTestHarness(t)
+	_, _, mobile := seedMixedRepositories(h)
+
+	state, diags := h.readDataSource("tenablesc_repository", map[string]interface{}{"name": "mobile"})
+	requireNoErrors(t, "read repository", diags)
+	if state.ID != mobile {
+		t.Fatalf("expected repository %s, got %s", mobile, state.ID)
+	}
+	requireAttribute(t, state, "type", "MDM")
+	requireAttribute(t, state, "data_format", "mobile")
+}
@@ -21,7 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourceRepository() *schema.Resource {
@@ -34,28 +34,38 @@ func DataSourceRepository() *schema.Resource {
 				Required:    true,
 				Description: fmt.Sprintf(descriptionDataSourceNameFindTemplate, "repository"),
 			},
+			"data_format": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionRepositoryFoundDataFormat,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionRepositoryFoundType,
+			},
 		},
 	}
 }
 
 func dataSourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	sc := m.(*tenablesc.Client)
+	sc := m.(*tenablesc.Client).WithCachedLists()
 
 	repoName := d.Get("name").(string)
 
-	Logf(logDebug, "looking up %s", repoName)
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": repoName})
 
 	repos, err := sc.GetAllRepositories()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	Logf(logDebug, "response: %+v", repos)
-
 	for _, repo := range repos {
-		Logf(logTrace, "comparing repository: %+v", *repo)
+		logTrace(ctx, "comparing repository", map[string]interface{}{logFieldObjectID: repo.ID, "name": repo.Name})
 		if strings.Compare(repo.Name, repoName) == 0 {
 			d.SetId(string(repo.ID))
+			d.Set("data_format", repo.DataFormat)
+			d.Set("type", repo.Type)
 			return nil
 		}
 	}
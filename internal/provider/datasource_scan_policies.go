@@ -0,0 +1,104 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+func DataSourceScanPolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScanPoliciesRead,
+		Description: descriptionDataSourceScanPolicies,
+		Schema: map[string]*schema.Schema{
+			"scan_policies": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: fmt.Sprintf(descriptionMapIDToNameTemplate, "scan policy", "scan policy"),
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"policy_template_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: descriptionScanPolicyTemplateIDs,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"name_filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     ".*",
+				Description: fmt.Sprintf(descriptionRegexpNameFilterTemplate, "scan policy"),
+			},
+		},
+	}
+}
+
+func dataSourceScanPoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sc := m.(*tenablesc.Client).WithCachedLists()
+
+	logDebug(ctx, "looking up all scan policies")
+
+	allPolicies, err := sc.GetAllScanPolicies()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies := make(map[string]interface{})
+	templateIDs := make(map[string]interface{})
+
+	nameFilter := d.Get("name_filter").(string)
+
+	d.SetId(fmt.Sprintf("scan_policies:%s", nameFilter))
+
+	var nameRE *regexp.Regexp
+
+	if len(nameFilter) == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("filter is empty string, will return no entries."),
+		}}
+	}
+
+	nameRE, err = regexp.Compile("^" + nameFilter + "$")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, policy := range allPolicies {
+		if nameRE.MatchString(policy.Name) {
+			policies[string(policy.ID)] = policy.Name
+			if policy.PolicyTemplate != nil {
+				templateIDs[string(policy.ID)] = string(policy.PolicyTemplate.ID)
+			}
+		}
+	}
+
+	logDebug(ctx, "filtered result set", map[string]interface{}{"count": len(policies)})
+
+	if len(policies) == 0 {
+		return diag.Errorf("no scan policies matching filter '^%s$'", nameFilter)
+	}
+
+	d.Set("scan_policies", policies)
+	d.Set("policy_template_ids", templateIDs)
+
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+func DataSourceScanPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScanPolicyRead,
+		Description: descriptionDataSourceScanPolicy,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: fmt.Sprintf(descriptionDataSourceNameFindTemplate, "scan policy"),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionScanPolicyDescription,
+			},
+			"policy_template_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionScanPolicyTemplateID,
+			},
+			"families": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: descriptionScanPolicyFamilies,
+			},
+			"audit_file_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: descriptionScanPolicyAuditFileIDs,
+			},
+			"tag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionScanPolicyTag,
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionScanPolicyOwner,
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descriptionScanPolicyOwnerID,
+			},
+		},
+	}
+}
+
+func dataSourceScanPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sc := m.(*tenablesc.Client).WithCachedLists()
+
+	policyName := d.Get("name").(string)
+
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": policyName})
+
+	policies, err := sc.GetAllScanPolicies()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, policy := range policies {
+		logTrace(ctx, "comparing scan policy", map[string]interface{}{logFieldObjectID: policy.ID, "name": policy.Name})
+		if policy.Name != policyName {
+			continue
+		}
+
+		d.SetId(string(policy.ID))
+		d.Set("description", policy.Description)
+		d.Set("tag", policy.Tags)
+
+		if policy.PolicyTemplate != nil {
+			d.Set("policy_template_id", policy.PolicyTemplate.ID)
+		} else {
+			d.Set("policy_template_id", "")
+		}
+
+		var families []string
+		for _, family := range policy.Families {
+			families = append(families, family.ID)
+		}
+		d.Set("families", families)
+		d.Set("audit_file_ids", unbundleIDs(policy.AuditFiles))
+
+		if policy.Owner != nil {
+			d.Set("owner", policy.Owner.Username)
+			d.Set("owner_id", policy.Owner.ID)
+		} else {
+			d.Set("owner", "")
+			d.Set("owner_id", "")
+		}
+
+		return nil
+	}
+
+	return diag.Errorf("No scan policy with name [%s] found", policyName)
+}
@@ -21,7 +21,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func DataSourceScanPolicyTemplate() *schema.Resource {
@@ -39,10 +39,10 @@ func DataSourceScanPolicyTemplate() *schema.Resource {
 }
 
 func dataSourceScanPolicyTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	sc := m.(*tenablesc.Client)
+	sc := m.(*tenablesc.Client).WithCachedLists()
 	name := d.Get("name").(string)
 
-	Logf(logDebug, "looking up %s", name)
+	logDebug(ctx, "looking up object by name", map[string]interface{}{"name": name})
 
 	templates, err := sc.GetAllScanPolicyTemplates()
 	if err != nil {
@@ -50,7 +50,7 @@ func dataSourceScanPolicyTemplateRead(ctx context.Context, d *schema.ResourceDat
 	}
 
 	for _, template := range templates {
-		Logf(logTrace, "comparing scan policy template: %+v", *template)
+		logTrace(ctx, "comparing scan policy template", map[string]interface{}{logFieldObjectID: template.ID, "name": template.Name})
 		if strings.Compare(template.Name, name) == 0 {
 			d.SetId(string(template.ID))
 			return nil
@@ -0,0 +1,79 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func seedScanPolicies(h *testHarness) (basic, audit string) {
+	basic = h.server.Seed(fakesc.EndpointPolicy, fakesc.Object{
+		"name":           "Basic Network Scan",
+		"description":    "central basic policy",
+		"tags":           "central",
+		"policyTemplate": fakesc.Object{"id": "1", "name": "Basic Network Scan"},
+		"owner":          fakesc.Object{"id": "7", "username": "secops"},
+		"families":       []interface{}{fakesc.Object{"id": "10"}, fakesc.Object{"id": "20"}},
+	})
+	audit = h.server.Seed(fakesc.EndpointPolicy, fakesc.Object{
+		"name":           "Linux Audit",
+		"policyTemplate": fakesc.Object{"id": "4", "name": "Policy Compliance Auditing"},
+		"auditFiles":     []interface{}{fakesc.Object{"id": "3"}},
+	})
+	return
+}
+
+func TestDataSourceScanPolicy(t *testing.T) {
+	h := newTestHarness(t)
+	basic, _ := seedScanPolicies(h)
+
+	state, diags := h.readDataSource("tenablesc_scan_policy", map[string]interface{}{"name": "Basic Network Scan"})
+	requireNoErrors(t, "read scan policy", diags)
+	if state.ID != basic {
+		t.Fatalf("expected scan policy %s, got %s", basic, state.ID)
+	}
+	requireAttribute(t, state, "description", "central basic policy")
+	requireAttribute(t, state, "policy_template_id", "1")
+	requireAttribute(t, state, "families.#", "2")
+	requireAttribute(t, state, "tag", "central")
+	requireAttribute(t, state, "owner", "secops")
+	requireAttribute(t, state, "owner_id", "7")
+
+	if _, diags := h.readDataSource("tenablesc_scan_policy", map[string]interface{}{"name": "Advanced Scan"}); !diags.HasError() {
+		t.Fatal("expected an error when no scan policy matches")
+	}
+}
+
+func TestDataSourceScanPolicies(t *testing.T) {
+	h := newTestHarness(t)
+	basic, audit := seedScanPolicies(h)
+
+	state, diags := h.readDataSource("tenablesc_scan_policies", map[string]interface{}{})
+	requireNoErrors(t, "read scan policies", diags)
+	requireAttribute(t, state, "scan_policies.%", "2")
+	requireAttribute(t, state, "scan_policies."+basic, "Basic Network Scan")
+	requireAttribute(t, state, "policy_template_ids."+audit, "4")
+
+	state, diags = h.readDataSource("tenablesc_scan_policies", map[string]interface{}{"name_filter": "Linux.*"})
+	requireNoErrors(t, "read filtered scan policies", diags)
+	requireAttribute(t, state, "scan_policies.%", "1")
+	requireAttribute(t, state, "scan_policies."+audit, "Linux Audit")
+
+	if _, diags := h.readDataSource("tenablesc_scan_policies", map[string]interface{}{"name_filter": "Windows.*"}); !diags.HasError() {
+		t.Fatal("expected an error when no scan policy matches the filter")
+	}
+}
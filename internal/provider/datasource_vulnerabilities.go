@@ -0,0 +1,401 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// vulnerabilityResultAttributes maps each analysis tool to the attribute its results are returned in.
+var vulnerabilityResultAttributes = map[string]string{
+	"sumip":         "ip_summaries",
+	"sumdnsname":    "dns_name_summaries",
+	"vulnipsummary": "vulnerability_summaries",
+	"vulndetails":   "vulnerabilities",
+}
+
+// analysisFilterReferences are filters whose values refer to other SC objects by ID, rather than being plain strings.
+// Repository filters take a list of references; the others a single one.
+var analysisFilterReferences = map[string]bool{
+	"repository": true,
+	"asset":      false,
+	"policy":     false,
+}
+
+func DataSourceVulnerabilities() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVulnerabilitiesRead,
+		Description: descriptionDataSourceVulnerabilities,
+		Schema: map[string]*schema.Schema{
+			"tool": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      descriptionAnalysisTool,
+				ValidateDiagFunc: validateAnalysisTool,
+			},
+			"source_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "cumulative",
+				Description:      descriptionAnalysisSourceType,
+				ValidateDiagFunc: validateAnalysisSourceType,
+			},
+			"scan_result_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptionAnalysisScanResultID,
+			},
+			"filter": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptionAnalysisFilter,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptionAnalysisFilterName,
+						},
+						"operator": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "=",
+							Description: descriptionAnalysisFilterOperator,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptionAnalysisFilterValue,
+						},
+					},
+				},
+			},
+			"sort_field": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptionAnalysisSortField,
+			},
+			"sort_direction": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "DESC",
+				Description:      descriptionAnalysisSortDirection,
+				ValidateDiagFunc: validateAnalysisSortDirection,
+			},
+			"start_offset": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: descriptionAnalysisStartOffset,
+			},
+			"end_offset": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: descriptionAnalysisEndOffset,
+			},
+			"total_records": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: descriptionAnalysisTotalRecords,
+			},
+			"returned_records": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: descriptionAnalysisReturnedRecords,
+			},
+			"ip_summaries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: descriptionAnalysisIPSummaries,
+				Elem: &schema.Resource{
+					Schema: withSeverityCounts(map[string]*schema.Schema{
+						"ip":            computedString(descriptionAnalysisIP),
+						"dns_name":      computedString(descriptionAnalysisDNSName),
+						"netbios_name":  computedString(descriptionAnalysisNetbiosName),
+						"mac_address":   computedString(descriptionAnalysisMacAddress),
+						"repository_id": computedString(descriptionRepositoryID),
+						"score":         computedInt(descriptionAnalysisScore),
+					}),
+				},
+			},
+			"dns_name_summaries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: descriptionAnalysisDNSNameSummaries,
+				Elem: &schema.Resource{
+					Schema: withSeverityCounts(map[string]*schema.Schema{
+						"dns_name":      computedString(descriptionAnalysisDNSName),
+						"repository_id": computedString(descriptionRepositoryID),
+						"score":         computedInt(descriptionAnalysisScore),
+					}),
+				},
+			},
+			"vulnerability_summaries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: descriptionAnalysisVulnerabilitySummaries,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"plugin_id":     computedString(descriptionPluginID),
+						"name":          computedString(descriptionPluginName),
+						"family":        computedString(descriptionAnalysisFamily),
+						"severity":      computedInt(descriptionAnalysisSeverity),
+						"repository_id": computedString(descriptionRepositoryID),
+						"total":         computedInt(descriptionAnalysisTotal),
+					},
+				},
+			},
+			"vulnerabilities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: descriptionAnalysisVulnerabilities,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"plugin_id":     computedString(descriptionPluginID),
+						"plugin_name":   computedString(descriptionPluginName),
+						"family":        computedString(descriptionAnalysisFamily),
+						"severity":      computedInt(descriptionAnalysisSeverity),
+						"ip":            computedString(descriptionAnalysisIP),
+						"dns_name":      computedString(descriptionAnalysisDNSName),
+						"netbios_name":  computedString(descriptionAnalysisNetbiosName),
+						"port":          computedInt(descriptionAnalysisPort),
+						"protocol":      computedString(descriptionAnalysisProtocol),
+						"repository_id": computedString(descriptionRepositoryID),
+						"first_seen":    computedInt(descriptionAnalysisFirstSeen),
+						"last_seen":     computedInt(descriptionAnalysisLastSeen),
+						"cve":           computedString(descriptionAnalysisCVE),
+						"vpr_score":     computedString(descriptionAnalysisVPRScore),
+						"synopsis":      computedString(descriptionAnalysisSynopsis),
+						"solution":      computedString(descriptionAnalysisSolution),
+						"accept_risk":   computedBool(descriptionAnalysisAcceptRisk),
+						"recast_risk":   computedBool(descriptionAnalysisRecastRisk),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVulnerabilitiesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sc := m.(*tenablesc.Client)
+
+	tool := d.Get("tool").(string)
+	sourceType := d.Get("source_type").(string)
+
+	analysis := &tenablesc.Analysis{
+		Type:          "vuln",
+		SourceType:    sourceType,
+		SortField:     d.Get("sort_field").(string),
+		SortDirection: d.Get("sort_direction").(string),
+		Columns:       []tenablesc.BaseInfo{},
+		StartOffset:   strconv.Itoa(d.Get("start_offset").(int)),
+		EndOffset:     strconv.Itoa(d.Get("end_offset").(int)),
+		Query: tenablesc.AnalysisQuery{
+			Type:       "vuln",
+			SourceType: sourceType,
+			Tool:       tool,
+			Filters:    buildAnalysisFilters(d.Get("filter").([]interface{})),
+		},
+	}
+
+	if sourceType == "individual" {
+		scanResultID, ok := d.GetOk("scan_result_id")
+		if !ok {
+			return diag.Errorf("source_type individual requires scan_result_id")
+		}
+		analysis.ScanID = scanResultID.(string)
+		analysis.View = "all"
+	}
+
+	logDebug(ctx, "querying vulnerabilities", map[string]interface{}{"tool": tool, "source_type": sourceType, "filters": len(analysis.Query.Filters)})
+
+	var results []map[string]interface{}
+	var resp *tenablesc.AnalysisResponseContainer
+	var err error
+
+	switch tool {
+	case "sumip":
+		var sumIPs []tenablesc.VulnSumIPResult
+		resp, err = sc.Analyze(analysis, &sumIPs)
+		for _, r := range sumIPs {
+			results = append(results, withSeverityCountValues(map[string]interface{}{
+				"ip":            r.IP,
+				"dns_name":      r.DNSName,
+				"netbios_name":  r.NetBiosName,
+				"mac_address":   r.MacAddress,
+				"repository_id": r.Repository.ID,
+				"score":         atoiOrZero(r.Score),
+			}, r.SeverityCritical, r.SeverityHigh, r.SeverityMedium, r.SeverityLow, r.SeverityInfo, r.Total))
+		}
+	case "sumdnsname":
+		var sumDNSNames []tenablesc.VulnSumDNSNameResult
+		resp, err = sc.Analyze(analysis, &sumDNSNames)
+		for _, r := range sumDNSNames {
+			results = append(results, withSeverityCountValues(map[string]interface{}{
+				"dns_name":      r.DNSName,
+				"repository_id": r.Repository.ID,
+				"score":         atoiOrZero(r.Score),
+			}, r.SeverityCritical, r.SeverityHigh, r.SeverityMedium, r.SeverityLow, r.SeverityInfo, r.Total))
+		}
+	case "vulnipsummary":
+		var summaries []tenablesc.VulnIPSummaryResult
+		resp, err = sc.Analyze(analysis, &summaries)
+		for _, r := range summaries {
+			results = append(results, map[string]interface{}{
+				"plugin_id":     r.PluginID,
+				"name":          r.Name,
+				"family":        r.Family.Name,
+				"severity":      atoiOrZero(string(r.Severity.ID)),
+				"repository_id": r.RepositoryID,
+				"total":         atoiOrZero(r.Total),
+			})
+		}
+	case "vulndetails":
+		var details []tenablesc.VulnDetailsResult
+		resp, err = sc.Analyze(analysis, &details)
+		for _, r := range details {
+			results = append(results, map[string]interface{}{
+				"plugin_id":     r.PluginID,
+				"plugin_name":   r.PluginName,
+				"family":        r.Family.Name,
+				"severity":      atoiOrZero(string(r.Severity.ID)),
+				"ip":            r.IP,
+				"dns_name":      r.DNSName,
+				"netbios_name":  r.NetbiosName,
+				"port":          atoiOrZero(r.Port),
+				"protocol":      r.Protocol,
+				"repository_id": r.Repository.ID,
+				"first_seen":    atoiOrZero(r.FirstSeen),
+				"last_seen":     atoiOrZero(r.LastSeen),
+				"cve":           r.CVE,
+				"vpr_score":     r.VPRScore,
+				"synopsis":      r.Synopsis,
+				"solution":      r.Solution,
+				"accept_risk":   r.AcceptRisk == "1",
+				"recast_risk":   r.RecastRisk == "1",
+			})
+		}
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("vulnerabilities:%s:%s", tool, sourceType))
+	d.Set("total_records", atoiOrZero(resp.TotalRecords))
+	d.Set("returned_records", resp.ReturnedRecords)
+
+	list := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		list = append(list, r)
+	}
+	d.Set(vulnerabilityResultAttributes[tool], list)
+
+	return nil
+}
+
+func buildAnalysisFilters(filters []interface{}) []tenablesc.AnalysisFilter {
+	var analysisFilters []tenablesc.AnalysisFilter
+
+	for _, f := range filters {
+		filter := f.(map[string]interface{})
+		name := filter["filter_name"].(string)
+
+		var value interface{} = filter["value"].(string)
+		if list, isReference := analysisFilterReferences[name]; isReference {
+			ref := tenablesc.BaseInfo{ID: tenablesc.ProbablyString(filter["value"].(string))}
+			if list {
+				value = []tenablesc.BaseInfo{ref}
+			} else {
+				value = ref
+			}
+		}
+
+		analysisFilters = append(analysisFilters, tenablesc.AnalysisFilter{
+			FilterName: name,
+			Operator:   filter["operator"].(string),
+			Value:      value,
+		})
+	}
+
+	return analysisFilters
+}
+
+func withSeverityCounts(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["severity_critical"] = computedInt(descriptionAnalysisSeverityCritical)
+	s["severity_high"] = computedInt(descriptionAnalysisSeverityHigh)
+	s["severity_medium"] = computedInt(descriptionAnalysisSeverityMedium)
+	s["severity_low"] = computedInt(descriptionAnalysisSeverityLow)
+	s["severity_info"] = computedInt(descriptionAnalysisSeverityInfo)
+	s["total"] = computedInt(descriptionAnalysisTotal)
+	return s
+}
+
+func withSeverityCountValues(r map[string]interface{}, critical, high, medium, low, info, total string) map[string]interface{} {
+	r["severity_critical"] = atoiOrZero(critical)
+	r["severity_high"] = atoiOrZero(high)
+	r["severity_medium"] = atoiOrZero(medium)
+	r["severity_low"] = atoiOrZero(low)
+	r["severity_info"] = atoiOrZero(info)
+	r["total"] = atoiOrZero(total)
+	return r
+}
+
+func computedString(description string) *schema.Schema {
+	return &schema.Schema{Type: schema.TypeString, Computed: true, Description: description}
+}
+
+func computedInt(description string) *schema.Schema {
+	return &schema.Schema{Type: schema.TypeInt, Computed: true, Description: description}
+}
+
+func computedBool(description string) *schema.Schema {
+	return &schema.Schema{Type: schema.TypeBool, Computed: true, Description: description}
+}
+
+// atoiOrZero converts a numeric SC field, treating unset or invalid values as 0.
+func atoiOrZero(s string) int {
+	return probablyInt(tenablesc.ProbablyString(s))
+}
+
+func validateAnalysisTool(i interface{}, path cty.Path) diag.Diagnostics {
+	if _, ok := vulnerabilityResultAttributes[i.(string)]; !ok {
+		return diag.Errorf("%s is not a supported analysis tool. Valid tools are sumip, sumdnsname, vulnipsummary and vulndetails", i)
+	}
+	return nil
+}
+
+func validateAnalysisSourceType(i interface{}, path cty.Path) diag.Diagnostics {
+	switch i.(string) {
+	case "cumulative", "patched", "individual":
+		return nil
+	}
+	return diag.Errorf("%s is not a valid source type. Valid source types are cumulative, patched and individual", i)
+}
+
+func validateAnalysisSortDirection(i interface{}, path cty.Path) diag.Diagnostics {
+	switch i.(string) {
+	case "ASC", "DESC":
+		return nil
+	}
+	return diag.Errorf("%s is not a valid sort direction. Valid directions are ASC and DESC", i)
+}
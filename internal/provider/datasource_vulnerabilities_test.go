@@ -0,0 +1,106 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestDataSourceVulnerabilitiesSumIP(t *testing.T) {
+	h := newTestHarness(t)
+
+	h.server.SetAnalysisResults("sumip", []fakesc.Object{
+		{"ip": "10.0.0.1", "dnsName": "a.example.com", "repository": fakesc.Object{"id": "1"}, "score": "50", "severityCritical": "1", "severityHigh": "2", "severityMedium": "0", "severityLow": "0", "severityInfo": "7", "total": "10"},
+		{"ip": "10.0.0.2", "repository": fakesc.Object{"id": "1"}, "score": "0", "severityCritical": "0", "severityInfo": "3", "total": "3"},
+		{"ip": "10.0.0.3", "repository": fakesc.Object{"id": "1"}, "score": "0", "total": "1"},
+	})
+
+	state, diags := h.readDataSource("tenablesc_vulnerabilities", map[string]interface{}{
+		"tool": "sumip",
+		"filter": []interface{}{
+			map[string]interface{}{"filter_name": "severity", "value": "4"},
+			map[string]interface{}{"filter_name": "repository", "value": "1"},
+			map[string]interface{}{"filter_name": "asset", "operator": "!=", "value": "12"},
+		},
+		"sort_field": "score",
+		"end_offset": 2,
+	})
+	requireNoErrors(t, "read vulnerabilities", diags)
+	requireAttribute(t, state, "total_records", "3")
+	requireAttribute(t, state, "returned_records", "2")
+	requireAttribute(t, state, "ip_summaries.#", "2")
+	requireAttribute(t, state, "ip_summaries.0.ip", "10.0.0.1")
+	requireAttribute(t, state, "ip_summaries.0.repository_id", "1")
+	requireAttribute(t, state, "ip_summaries.0.severity_critical", "1")
+	requireAttribute(t, state, "ip_summaries.0.total", "10")
+	requireAttribute(t, state, "ip_summaries.1.severity_high", "0")
+
+	analyses := h.server.Analyses()
+	if len(analyses) != 1 {
+		t.Fatalf("expected one analysis query, got %d", len(analyses))
+	}
+	query := analyses[0]["query"].(map[string]interface{})
+	if query["tool"] != "sumip" || query["sourceType"] != "cumulative" || analyses[0]["sortField"] != "score" {
+		t.Fatalf("unexpected analysis query: %v", analyses[0])
+	}
+	filters := query["filters"].([]interface{})
+	if filters[0].(map[string]interface{})["value"] != "4" {
+		t.Fatalf("expected plain severity filter value, got %v", filters[0])
+	}
+	if repos, ok := filters[1].(map[string]interface{})["value"].([]interface{}); !ok || repos[0].(map[string]interface{})["id"] != "1" {
+		t.Fatalf("expected repository filter to reference repositories by ID, got %v", filters[1])
+	}
+	if asset, ok := filters[2].(map[string]interface{})["value"].(map[string]interface{}); !ok || asset["id"] != "12" {
+		t.Fatalf("expected asset filter to reference an asset by ID, got %v", filters[2])
+	}
+}
+
+func TestDataSourceVulnerabilitiesDetails(t *testing.T) {
+	h := newTestHarness(t)
+
+	h.server.SetAnalysisResults("vulndetails", []fakesc.Object{
+		{
+			"pluginID": "156032", "pluginName": "Apache Log4j RCE", "ip": "10.0.0.1", "port": "443", "protocol": "TCP",
+			"severity": fakesc.Object{"id": "4", "name": "Critical"}, "family": fakesc.Object{"id": "1", "name": "Misc."},
+			"repository": fakesc.Object{"id": "1"}, "lastSeen": "1700000000", "acceptRisk": "0", "recastRisk": "1",
+		},
+	})
+
+	state, diags := h.readDataSource("tenablesc_vulnerabilities", map[string]interface{}{
+		"tool":           "vulndetails",
+		"source_type":    "individual",
+		"scan_result_id": "77",
+	})
+	requireNoErrors(t, "read vulnerabilities", diags)
+	requireAttribute(t, state, "vulnerabilities.#", "1")
+	requireAttribute(t, state, "vulnerabilities.0.severity", "4")
+	requireAttribute(t, state, "vulnerabilities.0.port", "443")
+	requireAttribute(t, state, "vulnerabilities.0.family", "Misc.")
+	requireAttribute(t, state, "vulnerabilities.0.last_seen", "1700000000")
+	requireAttribute(t, state, "vulnerabilities.0.recast_risk", "true")
+
+	if analysis := h.server.Analyses()[0]; analysis["scanID"] != "77" || analysis["sourceType"] != "individual" {
+		t.Fatalf("expected individual scan result to be queried, got %v", analysis)
+	}
+
+	if _, diags := h.readDataSource("tenablesc_vulnerabilities", map[string]interface{}{
+		"tool":        "vulndetails",
+		"source_type": "individual",
+	}); !diags.HasError() {
+		t.Fatal("expected individual source type without a scan result to fail")
+	}
+}
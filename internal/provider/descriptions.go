@@ -33,40 +33,62 @@ const (
 Requires Organization credentials.`
 	descriptionAdminCredentialsRequired = `
 Requires Administrator (org=0) credentials.`
+	descriptionRiskRuleReplaced = `
+SC can't change rules in place, so changes create a replacement rule before deleting the old one; the findings covered are never briefly uncovered.`
 
 	// Data Sources
 
+	descriptionDataSourceAgentGroups        = `Look up a set of agent group IDs on a Nessus Manager based on a regular expression name filter.` + descriptionOrgCredentialsRequired
 	descriptionDataSourceAsset              = `Look up an asset by name field.` + descriptionOrgCredentialsRequired
 	descriptionDataSourceAssets             = `Look up a set of asset IDs based on a regular expression name filter.` + descriptionOrgCredentialsRequired
 	descriptionDataSourceCredential         = `Look up a credential object ID by name field.`
+	descriptionDataSourceGroup              = `Look up a group by name field.` + descriptionOrgCredentialsRequired
 	descriptionDataSourcePlugin             = `Look up a plugin ID based on name.`
 	descriptionDataSourceRepositories       = `Look up a set of repositories based on a regular expression name filter.`
 	descriptionDataSourceRepository         = `Look up a repository ID based on name.`
+	descriptionDataSourceScanPolicies       = `Look up a set of scan policy IDs based on a regular expression name filter.` + descriptionOrgCredentialsRequired
+	descriptionDataSourceScanPolicy         = `Look up a scan policy by name field.` + descriptionOrgCredentialsRequired
 	descriptionDataSourceScanPolicyTemplate = `Look up a scan policy template ID based on name.`
+	descriptionDataSourceVulnerabilities    = `Query vulnerability data with an analysis tool. Results are returned in the attribute matching the tool.` + descriptionOrgCredentialsRequired
 
 	// Resources
-	descriptionResourceAcceptRisk                        = `Create and manage Accept Risk Rules.` + descriptionOrgCredentialsRequired
+	descriptionResourceAcceptRisk                        = `Create and manage Accept Risk Rules.` + descriptionRiskRuleReplaced + descriptionOrgCredentialsRequired
+	descriptionResourceAcceptRiskRules                   = `Manage Accept Risk Rules in a repository as a set of rule blocks. Refreshing lists the rules once, and applying creates and deletes only the rules of the blocks that changed; changing a block creates its replacement before deleting the old rule. Rules in the repository that are not in the set, such as those managed by ` + "`tenablesc_accept_risk`" + `, are left alone. Import with the repository ID to adopt every rule in it; importing fails if two of its rules are identical, as they would be the same block.` + descriptionOrgCredentialsRequired
+	descriptionResourceAgentScan                         = `Create and Manage Agent Scans.` + descriptionOrgCredentialsRequired
 	descriptionResourceAsset                             = `Create and manage Assets.` + descriptionOrgCredentialsRequired
 	descriptionResourceAuditFile                         = `Create and manage Audit Files.`
+	descriptionResourceCredential                        = `Create and manage scan Credentials. Secrets are write-only; only their hashes are kept in state, so changes made to them outside of Terraform are not detected.` + descriptionOrgCredentialsRequired
+	descriptionResourceGroup                             = `Create and manage Groups, which decide which assets and repositories their users can view.` + descriptionOrgCredentialsRequired
 	descriptionResourceOrganization                      = `Create and manage Organizations.` + descriptionAdminCredentialsRequired
 	descriptionResourceOrganizationScanZoneAssociation   = `Manage Scan Zones associated to an Organization.` + descriptionAdminCredentialsRequired
-	descriptionResourceRecastRisk                        = `Create and manage Recast Risk Rules.` + descriptionOrgCredentialsRequired
+	descriptionResourceRecastRisk                        = `Create and manage Recast Risk Rules.` + descriptionRiskRuleReplaced + descriptionOrgCredentialsRequired
+	descriptionResourceRecastRiskRules                   = `Manage Recast Risk Rules in a repository as a set of rule blocks. Refreshing lists the rules once, and applying creates and deletes only the rules of the blocks that changed; changing a block creates its replacement before deleting the old rule. Rules in the repository that are not in the set, such as those managed by ` + "`tenablesc_recast_risk`" + `, are left alone. Import with the repository ID to adopt every rule in it; importing fails if two of its rules are identical, as they would be the same block.` + descriptionOrgCredentialsRequired
 	descriptionResourceRepository                        = `Create and Manage Repositories.` + descriptionAdminCredentialsRequired
 	descriptionResourceRepositoryOrganizationAssociation = `Manage Organization access to Repositories.` + descriptionAdminCredentialsRequired
 	descriptionResourceRole                              = `Create and Manage User Roles.` + descriptionOrgCredentialsRequired
 	descriptionResourceScan                              = `Create and Manage Scans.` + descriptionOrgCredentialsRequired
+	descriptionResourceScanLaunch                        = `Launch an existing Scan and wait for it to finish. The scan is launched again whenever triggers change. If the create timeout passes or the apply is cancelled first, the scan is stopped.` + descriptionOrgCredentialsRequired
 	descriptionResourceScanPolicy                        = `Create and Manage Scan Policies.` + descriptionOrgCredentialsRequired
 	descriptionResourceScanZone                          = `Create and Manage Scan Zones.` + descriptionAdminCredentialsRequired
+	descriptionResourceUser                              = `Create and manage Users. The password is write-only; only its hash is kept in state, so changes made to it outside of Terraform are not detected.
+Requires Organization credentials to manage users of the credentials' own organization.
+Requires Administrator (org=0) credentials to manage users of the organization given by organization_id.`
 
 	// Fields
 	// Field descriptions should be brief and self-descriptive phrases, even if slightly redundant.
 	// Complex fields should include schema descriptions here.
 
 	// Field Names and Descriptions
+	descriptionAgentScanName           = `Agent Scan name`
+	descriptionAgentScanDescription    = `Agent Scan description`
 	descriptionAssetName               = `Asset name`
 	descriptionAssetDescription        = `Asset description`
 	descriptionAuditFileName           = `Audit file Name as presented in SC`
 	descriptionAuditFileDescription    = `Audit File description`
+	descriptionCredentialName          = `Credential name`
+	descriptionCredentialDescription   = `Credential description`
+	descriptionGroupName               = `Group name`
+	descriptionGroupDescription        = `Group description`
 	descriptionPluginName              = `Plugin name`
 	descriptionRepositoryName          = `Repository name`
 	descriptionRepositoryDescription   = `Repository description`
@@ -86,16 +108,166 @@ Requires Administrator (org=0) credentials.`
 	descriptionScanPolicyTemplateID    = `Scan Policy Template ID`
 	descriptionAuditFileID             = `Audit File ID`
 	descriptionOrganizationScanZoneIDs = `Scan Zone IDs to be allowed to be used by organization`
+	descriptionNessusManagerID         = `Nessus Manager scanner ID`
+	descriptionAgentScanAgentGroupIDs  = `Agent Group IDs on the Nessus Manager to scan`
+	descriptionRoleID                  = `Role ID`
+	descriptionScanID                  = `Scan ID`
+	descriptionGroupID                 = `Group ID`
+	descriptionGroupDefiningAssetIDs   = `Asset IDs defining what members of the group can view`
+	descriptionGroupRepositoryIDs      = `Repository IDs members of the group can view`
 
 	// Miscellaneous
 	descriptionAssetDefinedIPs      = `IP addresses defined in the asset`
 	descriptionAssetDefinedDNSNames = `DNS Names defined in the asset`
-	descriptionAssetType            = `Asset type - may be 'dnsname' or 'static'`
-	descriptionAssetValues          = `Asset values - must be either DNS names or IPs based on type of asset.`
+	descriptionAssetType            = `Asset type. May be:
+  * static
+  * dnsname
+  * watchlist
+  * dynamic
+  * combination
+  * ldapquery`
+	descriptionAssetValues           = `Asset values, for static, dnsname and watchlist assets - must be either DNS names or IPs based on type of asset.`
+	descriptionAssetRules            = `Rule tree, for dynamic assets`
+	descriptionAssetCombination      = `Boolean expression over asset IDs, for combination assets, e.g. '12 AND (13 OR NOT 14)'`
+	descriptionAssetLDAPQuery        = `LDAP query, for ldapquery assets`
+	descriptionAssetLDAPID           = `LDAP server ID`
+	descriptionAssetLDAPSearchBase   = `LDAP search base`
+	descriptionAssetLDAPSearchString = `LDAP search string`
+
+	descriptionAssetRuleGroupOperator = `How the group's clauses and groups are combined. May be:
+  * all (AND)
+  * any (OR)`
+	descriptionAssetRuleClause     = `Clause matching a single filter`
+	descriptionAssetRuleGroup      = `Nested rule group; groups may be nested three deep`
+	descriptionAssetRuleFilterName = `Filter name, e.g. 'ip', 'dns' or 'pluginText'`
+	descriptionAssetRuleOperator   = `Filter operator, e.g. '=', 'contains' or 'pcre'`
+	descriptionAssetRuleValue      = `Filter value`
+	descriptionAssetRulePluginID   = `Plugin ID constraining the filter, for plugin output filters`
 
 	descriptionAuditFileContent    = `Audit file content`
 	descriptionAuditFileSCFilename = `Filename of audit file as stored in SC`
 
+	descriptionGroupShareCreateUser = `Whether objects are shared with users created in the group (createUser)`
+	descriptionGroupShareAssets     = `Whether assets are shared with the group (assets)`
+	descriptionGroupSharePolicies   = `Whether policies are shared with the group (policies)`
+
+	descriptionScanLaunchTriggers     = `Arbitrary values which launch the scan again when changed`
+	descriptionScanLaunchFailOnError  = `Fail the apply if the scan finishes with status Error or Partial. Scans stopped or paused in SC don't fail it.`
+	descriptionScanResultStatus       = `Final scan result status, e.g. Completed, Partial or Error`
+	descriptionScanResultScannedIPs   = `Number of IPs scanned`
+	descriptionScanResultTotalIPs     = `Number of IPs targeted by the scan`
+	descriptionScanResultDuration     = `Scan duration in seconds`
+	descriptionScanResultErrorDetails = `Details of scan errors, if any`
+
+	descriptionAnalysisTool = `Analysis tool. May be:
+  * sumip - per-IP summaries, returned in ip_summaries
+  * sumdnsname - per-DNS name summaries, returned in dns_name_summaries
+  * vulnipsummary - per-plugin summaries, returned in vulnerability_summaries
+  * vulndetails - individual vulnerabilities, returned in vulnerabilities`
+	descriptionAnalysisSourceType = `Data to analyze. May be:
+  * cumulative
+  * patched
+  * individual - a single scan result, given by scan_result_id`
+	descriptionAnalysisScanResultID    = `Scan result ID, for source type individual`
+	descriptionAnalysisFilter          = `Filter to apply to the analysis, as seen in the SC vulnerability analysis UI`
+	descriptionAnalysisFilterName      = `Filter name, e.g. 'severity', 'pluginID', 'ip', 'repository', 'asset' or 'lastSeen'`
+	descriptionAnalysisFilterOperator  = `Filter operator, e.g. '=' or '!='`
+	descriptionAnalysisFilterValue     = `Filter value, e.g. '3,4' for high and critical severities. Repository and asset filters take an ID.`
+	descriptionAnalysisSortField       = `Field to sort results by, e.g. 'severity' or 'score'`
+	descriptionAnalysisSortDirection   = `Sort direction, ASC or DESC`
+	descriptionAnalysisStartOffset     = `Offset of the first result to return`
+	descriptionAnalysisEndOffset       = `Offset after the last result to return`
+	descriptionAnalysisTotalRecords    = `Number of results matching the query`
+	descriptionAnalysisReturnedRecords = `Number of results returned within the offsets`
+
+	descriptionAnalysisIPSummaries            = `Results of the sumip tool`
+	descriptionAnalysisDNSNameSummaries       = `Results of the sumdnsname tool`
+	descriptionAnalysisVulnerabilitySummaries = `Results of the vulnipsummary tool`
+	descriptionAnalysisVulnerabilities        = `Results of the vulndetails tool`
+
+	descriptionAnalysisIP               = `IP address`
+	descriptionAnalysisDNSName          = `DNS name`
+	descriptionAnalysisNetbiosName      = `NetBIOS name`
+	descriptionAnalysisMacAddress       = `MAC address`
+	descriptionAnalysisScore            = `Vulnerability score`
+	descriptionAnalysisFamily           = `Plugin family name`
+	descriptionAnalysisSeverity         = `Severity, from 0 (info) to 4 (critical)`
+	descriptionAnalysisTotal            = `Total vulnerability count`
+	descriptionAnalysisSeverityCritical = `Critical vulnerability count`
+	descriptionAnalysisSeverityHigh     = `High vulnerability count`
+	descriptionAnalysisSeverityMedium   = `Medium vulnerability count`
+	descriptionAnalysisSeverityLow      = `Low vulnerability count`
+	descriptionAnalysisSeverityInfo     = `Info vulnerability count`
+	descriptionAnalysisPort             = `Port`
+	descriptionAnalysisProtocol         = `Protocol`
+	descriptionAnalysisFirstSeen        = `Time first seen, in seconds since the epoch`
+	descriptionAnalysisLastSeen         = `Time last seen, in seconds since the epoch`
+	descriptionAnalysisCVE              = `Comma-separated CVE IDs`
+	descriptionAnalysisVPRScore         = `Vulnerability Priority Rating`
+	descriptionAnalysisSynopsis         = `Plugin synopsis`
+	descriptionAnalysisSolution         = `Plugin solution`
+	descriptionAnalysisAcceptRisk       = `Whether the vulnerability is covered by an accept risk rule`
+	descriptionAnalysisRecastRisk       = `Whether the vulnerability is covered by a recast risk rule`
+
+	descriptionUserUsername = `Username`
+	descriptionUserAuthType = `User authentication type. May be:
+  * password
+  * tls
+  * ldap
+  * saml`
+	descriptionUserPassword              = `Password, for password auth; stored in state only as a hash`
+	descriptionUserLDAPID                = `LDAP server ID, for ldap auth`
+	descriptionUserLDAPUsername          = `Username on the LDAP server, for ldap auth`
+	descriptionUserOrganizationID        = `Organization ID to create the user in. Requires Administrator credentials; leave unset to manage users with Organization credentials.`
+	descriptionUserResponsibilityAssetID = `Asset ID the user is responsible for`
+	descriptionUserLocked                = `Whether the user account is locked`
+	descriptionUserContactDetailTemplate = `User contact detail: %s`
+
+	descriptionCredentialTags     = `Tag for credential`
+	descriptionCredentialType     = `Credential type, as determined by which credential block is configured`
+	descriptionCredentialSSH      = `SSH credential. Exactly one of ssh, windows, database or snmp must be given.`
+	descriptionCredentialWindows  = `Windows credential. Exactly one of ssh, windows, database or snmp must be given.`
+	descriptionCredentialDatabase = `Database credential. Exactly one of ssh, windows, database or snmp must be given.`
+	descriptionCredentialSNMP     = `SNMP credential. Exactly one of ssh, windows, database or snmp must be given.`
+
+	descriptionCredentialSSHAuthType = `SSH authentication type. May be:
+  * password
+  * publicKey
+  * certificate`
+	descriptionCredentialWindowsAuthType     = `Windows authentication type, such as 'password' or 'kerberos'. Default: 'password'`
+	descriptionCredentialDatabaseAuthType    = `Database authentication type, such as 'password' or 'cyberark'. Default: 'password'`
+	descriptionCredentialUsername            = `Username to authenticate as`
+	descriptionCredentialPassword            = `Password; stored in state only as a hash`
+	descriptionCredentialPrivateKey          = `Private key content, for publicKey and certificate auth; stored in state only as a hash`
+	descriptionCredentialPublicKey           = `Certificate content, for certificate auth; stored in state only as a hash`
+	descriptionCredentialPassphrase          = `Passphrase for the private key; stored in state only as a hash`
+	descriptionCredentialPrivilegeEscalation = `Privilege escalation method. May be:
+  * none
+  * su
+  * sudo
+  * su+sudo
+  * dzdo
+  * pbrun
+  * cisco
+  * .k5login`
+	descriptionCredentialEscalationUsername = `Username to escalate privileges with`
+	descriptionCredentialEscalationPassword = `Password to escalate privileges with; stored in state only as a hash`
+	descriptionCredentialEscalationPath     = `Directory containing the privilege escalation command`
+	descriptionCredentialEscalationSuUser   = `User to su to, when combining su and sudo`
+	descriptionCredentialDomain             = `Windows domain`
+	descriptionCredentialDBType             = `Database type. May be:
+  * Oracle
+  * SQL Server
+  * DB2
+  * MySQL
+  * PostgreSQL
+  * Informix/DRDA`
+	descriptionCredentialDBPort            = `Database port`
+	descriptionCredentialDBSID             = `Database SID or name`
+	descriptionCredentialOracleAuthType    = `Oracle authentication type - may be 'NORMAL', 'SYSOPER' or 'SYSDBA'`
+	descriptionCredentialSQLServerAuthType = `SQL Server authentication type - may be 'SQL' or 'Windows'`
+	descriptionCredentialCommunityString   = `SNMP community string; stored in state only as a hash`
+
 	descriptionRiskRuleHostType  = `Host Type may be 'all', 'ip', or 'asset'`
 	descriptionRiskRuleHostValue = `A list of values depending on the host type.
   * Must be empty for type 'all'; 
@@ -108,8 +280,17 @@ Requires Administrator (org=0) credentials.`
   * icmp
   * unknown 
   * any `
-	descriptionComments             = `Comments`
-	descriptionAcceptRiskExpiration = `Expiration date for accept risk rule in RFC3339 format`
+	descriptionComments              = `Comments`
+	descriptionAcceptRiskExpiration  = `Expiration date for accept risk rule in RFC3339 format`
+	descriptionAcceptRiskExpiresIn   = `How long the rule lasts from when it is created or renewed, as days like '90d', weeks like '2w' or a duration like '36h'. Conflicts with 'expiration'.`
+	descriptionAcceptRiskRenewBefore = `Renew the rule when a plan runs this long or less before it expires, in the same format as 'expires_in'. Renewing pushes the expiration forward by 'expires_in' and records who reapproved the rule in its comments in SC.`
+	descriptionAcceptRiskExpiresAt   = `When the rule expires in SC, in RFC3339 format, or '-1' if it does not`
+	descriptionAcceptRiskRenewal     = `Who last reapproved the rule by renewing it, and when, as recorded in its comments in SC`
+	descriptionAcceptRiskLapsed      = `Whether the rule expired and SC deleted it. Plans replace lapsed rules.`
+
+	descriptionAcceptRiskRulesExpiration = `Expiration date for the rule in RFC3339 format, or '-1' if it does not expire. SC deletes expired rules, so the next plan creates them again.`
+	descriptionRiskRuleSetRule           = `The rules managed in the repository. Each block is one rule in SC.`
+	descriptionRiskRuleSetRuleIDs        = `The SC ID of the rule each block created, keyed by the block's hash`
 
 	descriptionOrganizationZoneSelection = `Scan Zone Selection for organization. May be:
  * auto_only
@@ -126,10 +307,25 @@ Requires Administrator (org=0) credentials.`
   * 3 - High
   * 4 - Critical`
 
-	descriptionRepositoryIPRange     = `Range of IPs allowed to be stored in the repository - may be CIDR or Range format`
-	descriptionTrendingDays          = `Days to store trend data`
-	descriptionTrendWithRaw          = `Store raw data with trends`
-	descriptionVulnerabilityLifetime = `Specify custom storage durations in days for types of vulnerabilities`
+	descriptionRepositoryIPRange    = `Range of IPs allowed to be stored in the repository - may be CIDR or Range format. Required for Local and Offline IPv4 and IPv6 repositories.`
+	descriptionRepositoryDataFormat = `Repository data format. May be:
+  * IPv4
+  * IPv6
+  * agent
+  * universal`
+	descriptionRepositoryType = `Repository type. May be:
+  * Local
+  * Remote
+  * Offline`
+	descriptionRepositoryRemoteHost         = `Host of the SC to sync a Remote repository from`
+	descriptionRepositoryRemoteRepositoryID = `ID of the repository to sync on the remote SC, for Remote repositories`
+	descriptionRepositoryFoundType          = `Repository type, which may be one not modeled by tenablesc_repository`
+	descriptionRepositoryFoundDataFormat    = `Repository data format, which may be one not modeled by tenablesc_repository`
+	descriptionRepositoryTypes              = `A map of repository IDs to repository types, including types not modeled by tenablesc_repository`
+	descriptionRepositoryDataFormats        = `A map of repository IDs to repository data formats, including data formats not modeled by tenablesc_repository`
+	descriptionTrendingDays                 = `Days to store trend data`
+	descriptionTrendWithRaw                 = `Store raw data with trends`
+	descriptionVulnerabilityLifetime        = `Specify custom storage durations in days for types of vulnerabilities`
 
 	descriptionGroupAssignment = `Access within organization to grant to repository. Valid values are:
  * all
@@ -140,6 +336,16 @@ Requires Administrator (org=0) credentials.`
 	descriptionScanPolicyFamilies      = `Plugin Families to include in scan`
 	descriptionScanPolicyFamiliesState = `Plugin Families state to include in scan. Must be set to 'unlocked' for Tenable.SC 6x`
 	descriptionScanPolicyTag           = `Tag for scan policy`
+	descriptionScanPolicyAuditFileIDs  = `Audit File IDs attached to the scan policy`
+	descriptionScanPolicyOwner         = `Username of the scan policy owner`
+	descriptionScanPolicyOwnerID       = `User ID of the scan policy owner`
+	descriptionScanPolicyTemplateIDs   = `A map of scan policy IDs to scan policy template IDs`
 
 	descriptionScanZoneCIDRs = `CIDR blocks included in scan zone`
+
+	descriptionAgentScanWindow    = `Minutes to wait for agent results after the scan is launched`
+	descriptionEmailOnLaunch      = `Email the owner when the scan is launched`
+	descriptionEmailOnFinish      = `Email the owner when the scan finishes`
+	descriptionScheduleStart      = `Schedule start in iCal DTSTART form, e.g. 'TZID=America/New_York:20190909T200000'. Leave empty for an on-demand scan.`
+	descriptionScheduleRepeatRule = `Schedule repeat rule in iCal RRULE form, e.g. 'FREQ=DAILY;INTERVAL=1'`
 )
@@ -0,0 +1,464 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// generatedObject is an object of SC configuration is generated for.
+type generatedObject struct {
+	id   string
+	name string
+}
+
+// generatedType describes how configuration is generated for the objects of a resource type.
+type generatedType struct {
+	resourceType string
+	scope        credentialScope
+	list         func(*tenablesc.Client) ([]generatedObject, error)
+	// include decides on objects after they are read, for resources only some objects need.
+	include func(d *schema.ResourceData) bool
+	// complete sets attributes that must be configured but that the resource doesn't read back.
+	complete func(client *tenablesc.Client, d *schema.ResourceData) error
+
+	// references map attributes holding IDs of other objects, nested ones by their path, to the
+	// resource type of those objects, and expressionReferences attributes holding expressions over them.
+	references           map[string]string
+	expressionReferences map[string]string
+}
+
+// generatedTypes are the resource types configuration is generated for, in the order it is written in.
+// tenablesc_scan_launch is left out, as it is not an object of SC.
+var generatedTypes = []generatedType{
+	{
+		resourceType: "tenablesc_organization",
+		scope:        scopeAdmin,
+		list: listed((*tenablesc.Client).GetAllOrganizations, func(o *tenablesc.Organization) (string, string) {
+			return string(o.ID), o.Name
+		}),
+		complete:   completeOrganization,
+		references: map[string]string{"scan_zone_ids": "tenablesc_scan_zone"},
+	},
+	{
+		resourceType: "tenablesc_scan_zone",
+		scope:        scopeAdmin,
+		list: listed((*tenablesc.Client).GetAllScanZones, func(z *tenablesc.ScanZone) (string, string) {
+			return string(z.ID), z.Name
+		}),
+	},
+	{
+		resourceType: "tenablesc_organization_scan_zone_association",
+		scope:        scopeAdmin,
+		list: listed((*tenablesc.Client).GetAllOrganizations, func(o *tenablesc.Organization) (string, string) {
+			return string(o.ID), o.Name
+		}),
+		include: func(d *schema.ResourceData) bool {
+			return d.Get("scan_zone_ids").(*schema.Set).Len() > 0
+		},
+		references: map[string]string{
+			"organization_id": "tenablesc_organization",
+			"scan_zone_ids":   "tenablesc_scan_zone",
+		},
+	},
+	{
+		resourceType: "tenablesc_repository",
+		scope:        scopeAdmin,
+		list: listed((*tenablesc.Client).GetAllRepositories, func(r *tenablesc.Repository) (string, string) {
+			return string(r.ID), r.Name
+		}),
+	},
+	{
+		resourceType: "tenablesc_repository_organization_association",
+		scope:        scopeAdmin,
+		list: listed((*tenablesc.Client).GetAllRepositories, func(r *tenablesc.Repository) (string, string) {
+			return string(r.ID), r.Name
+		}),
+		include: func(d *schema.ResourceData) bool {
+			return len(d.Get("organization").([]interface{})) > 0
+		},
+		references: map[string]string{
+			"repository_id":                "tenablesc_repository",
+			"organization.organization_id": "tenablesc_organization",
+		},
+	},
+	{
+		resourceType: "tenablesc_auditfile",
+		scope:        scopeAny,
+		list: listed((*tenablesc.Client).GetAllAuditFiles, func(a *tenablesc.AuditFile) (string, string) {
+			return string(a.ID), a.Name
+		}),
+	},
+	{
+		resourceType: "tenablesc_scan_policy",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllScanPolicies, func(p *tenablesc.ScanPolicy) (string, string) {
+			return string(p.ID), p.Name
+		}),
+		references: map[string]string{"audit_file_id": "tenablesc_auditfile"},
+	},
+	{
+		resourceType: "tenablesc_asset",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllAssets, func(a *tenablesc.Asset) (string, string) {
+			return string(a.ID), a.Name
+		}),
+		expressionReferences: map[string]string{"combination": "tenablesc_asset"},
+	},
+	{
+		resourceType: "tenablesc_credential",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllCredentials, func(c *tenablesc.Credential) (string, string) {
+			return string(c.ID), c.Name
+		}),
+	},
+	{
+		resourceType: "tenablesc_scan",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllScans, func(s *tenablesc.Scan) (string, string) {
+			return string(s.ID), s.Name
+		}),
+		references: map[string]string{
+			"repository_id":  "tenablesc_repository",
+			"policy_id":      "tenablesc_scan_policy",
+			"asset_ids":      "tenablesc_asset",
+			"credential_ids": "tenablesc_credential",
+		},
+	},
+	{
+		resourceType: "tenablesc_agent_scan",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllAgentScans, func(s *tenablesc.AgentScan) (string, string) {
+			return string(s.ID), s.Name
+		}),
+		references: map[string]string{"repository_id": "tenablesc_repository"},
+	},
+	{
+		resourceType: "tenablesc_group",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllGroups, func(g *tenablesc.Group) (string, string) {
+			return string(g.ID), g.Name
+		}),
+		references: map[string]string{
+			"defining_asset_ids": "tenablesc_asset",
+			"repository_ids":     "tenablesc_repository",
+		},
+	},
+	{
+		resourceType: "tenablesc_role",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllRoles, func(r *tenablesc.Role) (string, string) {
+			return string(r.ID), r.Name
+		}),
+	},
+	{
+		resourceType: "tenablesc_user",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllUsers, func(u *tenablesc.User) (string, string) {
+			return string(u.ID), u.Username
+		}),
+		references: map[string]string{
+			"role_id":                 "tenablesc_role",
+			"group_id":                "tenablesc_group",
+			"responsibility_asset_id": "tenablesc_asset",
+			"organization_id":         "tenablesc_organization",
+		},
+	},
+	{
+		resourceType: "tenablesc_accept_risk",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllAcceptRiskRules, func(r *tenablesc.AcceptRiskRule) (string, string) {
+			if r.Plugin == nil {
+				return r.ID, ""
+			}
+			return r.ID, "plugin " + string(r.Plugin.ID)
+		}),
+		references: map[string]string{"repository_id": "tenablesc_repository"},
+	},
+	{
+		resourceType: "tenablesc_recast_risk",
+		scope:        scopeOrganization,
+		list: listed((*tenablesc.Client).GetAllRecastRiskRules, func(r *tenablesc.RecastRiskRule) (string, string) {
+			return r.ID, "plugin " + string(r.Plugin.ID)
+		}),
+		references: map[string]string{"repository_id": "tenablesc_repository"},
+	},
+}
+
+// listed adapts a GetAll method of the client to generatedType.list; identify returns the ID and
+// name of an object listed.
+func listed[T any](list func(*tenablesc.Client) ([]T, error), identify func(T) (string, string)) func(*tenablesc.Client) ([]generatedObject, error) {
+	return func(c *tenablesc.Client) ([]generatedObject, error) {
+		objects, err := list(c)
+		if err != nil {
+			return nil, err
+		}
+
+		generated := make([]generatedObject, 0, len(objects))
+		for _, object := range objects {
+			id, name := identify(object)
+			generated = append(generated, generatedObject{id: id, name: name})
+		}
+		return generated, nil
+	}
+}
+
+// completeOrganization sets the scan zones of organizations with selectable zones. These are assigned by
+// tenablesc_organization_scan_zone_association, so the organization doesn't read them back, but they
+// must be configured on it too.
+func completeOrganization(client *tenablesc.Client, d *schema.ResourceData) error {
+	switch d.Get("zone_selection").(string) {
+	case "selectable", "selectable+auto_restricted":
+	default:
+		return nil
+	}
+
+	organization, err := client.GetOrganization(d.Id())
+	if err != nil {
+		return err
+	}
+
+	zoneIDs := make([]string, 0, len(organization.Zones))
+	for _, zone := range organization.Zones {
+		zoneIDs = append(zoneIDs, string(zone.ID))
+	}
+	return d.Set("scan_zone_ids", zoneIDs)
+}
+
+// generatedResource is an object read for configuration to be generated.
+type generatedResource struct {
+	typ   generatedType
+	id    string
+	label string
+	data  *schema.ResourceData
+}
+
+// traversal returns the address of the resource configured for the object.
+func (r generatedResource) traversal() hcl.Traversal {
+	return hcl.Traversal{hcl.TraverseRoot{Name: r.typ.resourceType}, hcl.TraverseAttr{Name: r.label}}
+}
+
+// Generate writes Terraform configuration for the objects in SC that the provider can manage to w,
+// along with import blocks adopting them, for the given resource types or, if none are, all of them.
+// The provider is configured from the environment, as it is when its provider block leaves out
+// attributes: TENABLESC_URI and the variables of its credentials must be set. Object types the
+// credentials can't manage are left out with a warning.
+func Generate(ctx context.Context, w io.Writer, resourceTypes []string) diag.Diagnostics {
+	p := Provider()
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{})
+	if diags := p.Validate(config); diags.HasError() {
+		return diags
+	}
+	diags := p.Configure(ctx, config)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, generateConfig(ctx, p, w, resourceTypes)...)
+}
+
+func generateConfig(ctx context.Context, p *schema.Provider, w io.Writer, resourceTypes []string) diag.Diagnostics {
+	types, diags := selectGeneratedTypes(resourceTypes)
+	if diags.HasError() {
+		return diags
+	}
+
+	clients := p.Meta().(*providerClients)
+	users := map[*tenablesc.Client]*tenablesc.CurrentUser{}
+
+	var resources []generatedResource
+	for _, t := range types {
+		client, err := clients.client(t.scope)
+		if err == nil && t.scope != scopeAny {
+			if users[client] == nil {
+				users[client], err = client.WithContext(ctx).GetCurrentUser()
+			}
+			if err == nil {
+				err = t.scope.check(users[client])
+			}
+		}
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Skipped %s", t.resourceType),
+				Detail:   fmt.Sprintf("%s objects are managed with %s credentials: %s", t.resourceType, t.scope, err),
+			})
+			continue
+		}
+
+		read, readDiags := readGeneratedType(ctx, p, client.WithContext(ctx), t)
+		diags = append(diags, readDiags...)
+		resources = append(resources, read...)
+	}
+
+	addresses := map[string]map[string]hcl.Traversal{}
+	for _, r := range resources {
+		if addresses[r.typ.resourceType] == nil {
+			addresses[r.typ.resourceType] = map[string]hcl.Traversal{}
+		}
+		addresses[r.typ.resourceType][r.id] = r.traversal()
+	}
+
+	if err := writeGeneratedConfig(w, p, resources, addresses); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+func selectGeneratedTypes(resourceTypes []string) ([]generatedType, diag.Diagnostics) {
+	if len(resourceTypes) == 0 {
+		return generatedTypes, nil
+	}
+
+	selected := map[string]bool{}
+	for _, rt := range resourceTypes {
+		selected[rt] = true
+	}
+
+	var types []generatedType
+	for _, t := range generatedTypes {
+		if selected[t.resourceType] {
+			types = append(types, t)
+			delete(selected, t.resourceType)
+		}
+	}
+
+	var diags diag.Diagnostics
+	for rt := range selected {
+		diags = append(diags, diag.Errorf("configuration can't be generated for resource type %q", rt)...)
+	}
+	return types, diags
+}
+
+// readGeneratedType reads the objects of t through the resource's own read function,
+// so the configuration generated matches what the resource would read back after import.
+func readGeneratedType(ctx context.Context, p *schema.Provider, client *tenablesc.Client, t generatedType) ([]generatedResource, diag.Diagnostics) {
+	objects, err := t.list(client)
+	if err != nil {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Skipped %s", t.resourceType),
+			Detail:   fmt.Sprintf("failed to list %s objects: %s", t.resourceType, err),
+		}}
+	}
+
+	res := p.ResourcesMap[t.resourceType]
+	kind := strings.TrimPrefix(t.resourceType, "tenablesc_")
+
+	var diags diag.Diagnostics
+	var resources []generatedResource
+	labels := map[string]int{}
+	for _, object := range objects {
+		d := res.Data(&terraform.InstanceState{ID: object.id})
+		if readDiags := res.ReadContext(ctx, d, p.Meta()); readDiags.HasError() {
+			for _, rd := range readDiags {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Skipped %s %s", t.resourceType, object.id),
+					Detail:   rd.Summary,
+				})
+			}
+			continue
+		}
+		if d.Id() == "" || (t.include != nil && !t.include(d)) {
+			continue
+		}
+		if t.complete != nil {
+			if err := t.complete(client, d); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Skipped %s %s", t.resourceType, object.id),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+		}
+
+		r := generatedResource{typ: t, id: object.id, label: hclLabel(kind, object.name), data: d}
+		labels[r.label]++
+		resources = append(resources, r)
+	}
+
+	// names need not be unique in SC; tell objects sharing one apart by their IDs.
+	for i, r := range resources {
+		if labels[r.label] > 1 {
+			resources[i].label = hclLabel(kind, r.label+"_"+r.id)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].label < resources[j].label })
+
+	return resources, diags
+}
+
+func writeGeneratedConfig(w io.Writer, p *schema.Provider, resources []generatedResource, addresses map[string]map[string]hcl.Traversal) error {
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+	root.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# Generated by terraform-provider-tenablesc generate.\n")},
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# SC does not return passwords, keys and other secrets; add them to the resources that need them.\n")},
+	})
+
+	for _, r := range resources {
+		res := p.ResourcesMap[r.typ.resourceType]
+
+		values := map[string]interface{}{}
+		for k := range res.Schema {
+			values[k] = r.data.Get(k)
+		}
+
+		// reference returns the ID attribute of the resource configured for the object of type target.
+		reference := func(target, id string) (hcl.Traversal, bool) {
+			address, ok := addresses[target][id]
+			if !ok {
+				return nil, false
+			}
+			return append(address[:len(address):len(address)], hcl.TraverseAttr{Name: "id"}), true
+		}
+		body := hclBody{
+			reference: func(path, id string) (hcl.Traversal, bool) {
+				return reference(r.typ.references[path], id)
+			},
+			expressionReference: func(path, id string) (hcl.Traversal, bool) {
+				return reference(r.typ.expressionReferences[path], id)
+			},
+		}
+
+		root.AppendNewline()
+		importBlock := root.AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", r.traversal())
+		importBlock.SetAttributeValue("id", cty.StringVal(r.id))
+
+		root.AppendNewline()
+		resource := root.AppendNewBlock("resource", []string{r.typ.resourceType, r.label}).Body()
+		body.writeAttributes(resource, "", res.Schema, values)
+	}
+
+	_, err := f.WriteTo(w)
+	return err
+}
@@ -0,0 +1,259 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hclBody fills the body of a resource block from values read into its schema.
+type hclBody struct {
+	// reference returns the traversal to an object ID held by the attribute at path,
+	// which is its key, and that of blocks enclosing it, joined with dots.
+	reference func(path, id string) (hcl.Traversal, bool)
+	// expressionReference returns the traversal to an object ID embedded in the string
+	// held by the attribute at path, if there is one.
+	expressionReference func(path, id string) (hcl.Traversal, bool)
+}
+
+// writeAttributes sets the attributes and blocks in s to values in body. Attributes that are only
+// computed, sensitive, or hold their default or zero value are left out, as they would be when
+// written by hand; SC does not return sensitive values anyway.
+func (h hclBody) writeAttributes(body *hclwrite.Body, path string, s map[string]*schema.Schema, values map[string]interface{}) {
+	var attributes, blocks []string
+	for k, sch := range s {
+		if !isWritten(sch, values[k]) {
+			continue
+		}
+		if _, ok := sch.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+		} else {
+			attributes = append(attributes, k)
+		}
+	}
+	sort.Strings(attributes)
+	sort.Strings(blocks)
+
+	for _, k := range attributes {
+		if tokens, ok := h.references(joinPath(path, k), s[k], values[k]); ok {
+			body.SetAttributeRaw(k, tokens)
+		} else {
+			body.SetAttributeValue(k, ctyValue(s[k], values[k]))
+		}
+	}
+
+	for _, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+
+		var nested []*hclwrite.Block
+		for _, v := range collectionElements(values[k]) {
+			block := hclwrite.NewBlock(k, nil)
+			h.writeAttributes(block.Body(), joinPath(path, k), elem.Schema, v.(map[string]interface{}))
+			nested = append(nested, block)
+		}
+		if s[k].Type == schema.TypeSet {
+			sort.Slice(nested, func(i, j int) bool {
+				return string(nested[i].BuildTokens(nil).Bytes()) < string(nested[j].BuildTokens(nil).Bytes())
+			})
+		}
+
+		for _, block := range nested {
+			body.AppendNewline()
+			body.AppendBlock(block)
+		}
+	}
+}
+
+// references returns the expression for v, the value of the attribute at path, if it refers to
+// other objects.
+func (h hclBody) references(path string, sch *schema.Schema, v interface{}) (hclwrite.Tokens, bool) {
+	switch sch.Type {
+	case schema.TypeList, schema.TypeSet:
+		elem := elemSchema(sch)
+
+		var elements []hclwrite.Tokens
+		referenced := false
+		for _, e := range collectionElements(v) {
+			tokens, ok := h.references(path, elem, e)
+			if !ok {
+				tokens = hclwrite.TokensForValue(ctyValue(elem, e))
+			}
+			elements = append(elements, tokens)
+			referenced = referenced || ok
+		}
+		if !referenced {
+			return nil, false
+		}
+		if sch.Type == schema.TypeSet {
+			sort.Slice(elements, func(i, j int) bool { return string(elements[i].Bytes()) < string(elements[j].Bytes()) })
+		}
+		return hclwrite.TokensForTuple(elements), true
+	case schema.TypeMap:
+		return nil, false
+	}
+
+	if h.reference != nil {
+		if id := fmt.Sprint(v); id != "" {
+			if traversal, ok := h.reference(path, id); ok {
+				return hclwrite.TokensForTraversal(traversal), true
+			}
+		}
+	}
+	if s, ok := v.(string); ok && h.expressionReference != nil {
+		return h.template(path, s)
+	}
+	return nil, false
+}
+
+var expressionIDPattern = regexp.MustCompile(`\b[0-9]+\b`)
+
+// template returns s as a string template with the object IDs in it replaced by references,
+// if any of them can be.
+func (h hclBody) template(path, s string) (hclwrite.Tokens, bool) {
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)}}
+	literal := func(s string) {
+		// the quotes around the literal are those of the template.
+		quoted := hclwrite.TokensForValue(cty.StringVal(s))
+		tokens = append(tokens, quoted[1:len(quoted)-1]...)
+	}
+
+	referenced := false
+	last := 0
+	for _, match := range expressionIDPattern.FindAllStringIndex(s, -1) {
+		traversal, ok := h.expressionReference(path, s[match[0]:match[1]])
+		if !ok {
+			continue
+		}
+		literal(s[last:match[0]])
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")})
+		tokens = append(tokens, hclwrite.TokensForTraversal(traversal)...)
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")})
+		last = match[1]
+		referenced = true
+	}
+	literal(s[last:])
+
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)}), referenced
+}
+
+// ctyValue converts v, as read into an attribute described by sch, to a value to write.
+func ctyValue(sch *schema.Schema, v interface{}) cty.Value {
+	switch sch.Type {
+	case schema.TypeList, schema.TypeSet:
+		var elements []cty.Value
+		for _, e := range collectionElements(v) {
+			elements = append(elements, ctyValue(elemSchema(sch), e))
+		}
+		if sch.Type == schema.TypeSet {
+			return cty.SetVal(elements)
+		}
+		return cty.ListVal(elements)
+	case schema.TypeMap:
+		elements := map[string]cty.Value{}
+		for k, e := range v.(map[string]interface{}) {
+			elements[k] = ctyValue(elemSchema(sch), e)
+		}
+		return cty.MapVal(elements)
+	}
+
+	switch v := v.(type) {
+	case string:
+		return cty.StringVal(v)
+	case int:
+		return cty.NumberIntVal(int64(v))
+	case float64:
+		return cty.NumberFloatVal(v)
+	case bool:
+		return cty.BoolVal(v)
+	}
+	return cty.StringVal(fmt.Sprint(v))
+}
+
+// elemSchema returns the schema of the elements of a collection of primitives.
+func elemSchema(sch *schema.Schema) *schema.Schema {
+	if e, ok := sch.Elem.(*schema.Schema); ok {
+		return e
+	}
+	return &schema.Schema{Type: schema.TypeString}
+}
+
+// isWritten returns whether the attribute described by sch needs to be configured as v.
+func isWritten(sch *schema.Schema, v interface{}) bool {
+	switch {
+	case sch.Computed && !sch.Optional, sch.Sensitive:
+		return false
+	case sch.Required:
+		return true
+	case sch.Default != nil:
+		return fmt.Sprint(v) != fmt.Sprint(sch.Default)
+	}
+	return !isZero(v)
+}
+
+func isZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return len(collectionElements(v)) == 0
+}
+
+func collectionElements(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+func joinPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return path + "." + k
+}
+
+var hclLabelPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// hclLabel turns name into a resource name, prefixing it with kind where it would not otherwise
+// be a valid identifier.
+func hclLabel(kind, name string) string {
+	label := strings.Trim(hclLabelPattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = strings.Trim(kind+"_"+label, "_")
+	}
+	return label
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// generatedBlock is a resource or import block of generated configuration, evaluated.
+type generatedBlock struct {
+	labels []string
+	config map[string]interface{}
+}
+
+// parseGeneratedConfig parses generated configuration, resolving the references in it to the objects
+// the import blocks in it adopt.
+func parseGeneratedConfig(t *testing.T, src []byte) (resources, imports []generatedBlock) {
+	t.Helper()
+
+	file, diags := hclsyntax.ParseConfig(src, "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("generated configuration is not valid HCL: %s\n%s", diags, src)
+	}
+
+	// import blocks name their resource by traversal; resolve those first.
+	ids := map[string]map[string]cty.Value{}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "import" {
+			continue
+		}
+		to, diags := hcl.AbsTraversalForExpr(block.Body.Attributes["to"].Expr)
+		if diags.HasErrors() {
+			t.Fatalf("invalid import block target: %s", diags)
+		}
+		id, _ := block.Body.Attributes["id"].Expr.Value(nil)
+
+		resourceType, label := to.RootName(), to[1].(hcl.TraverseAttr).Name
+		if ids[resourceType] == nil {
+			ids[resourceType] = map[string]cty.Value{}
+		}
+		ids[resourceType][label] = cty.ObjectVal(map[string]cty.Value{"id": id})
+		imports = append(imports, generatedBlock{labels: []string{resourceType, label}, config: map[string]interface{}{"id": id.AsString()}})
+	}
+
+	ctx := &hcl.EvalContext{Variables: map[string]cty.Value{}}
+	for resourceType, objects := range ids {
+		ctx.Variables[resourceType] = cty.ObjectVal(objects)
+	}
+
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type == "resource" {
+			resources = append(resources, generatedBlock{labels: block.Labels, config: evaluateBody(t, ctx, block.Body)})
+		}
+	}
+	return resources, imports
+}
+
+func evaluateBody(t *testing.T, ctx *hcl.EvalContext, body *hclsyntax.Body) map[string]interface{} {
+	t.Helper()
+
+	config := map[string]interface{}{}
+	for name, attribute := range body.Attributes {
+		v, diags := attribute.Expr.Value(ctx)
+		if diags.HasErrors() {
+			t.Fatalf("evaluating %s: %s", name, diags)
+		}
+
+		b, err := ctyjson.Marshal(v, v.Type())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var value interface{}
+		if err := json.Unmarshal(b, &value); err != nil {
+			t.Fatal(err)
+		}
+		config[name] = value
+	}
+	for _, block := range body.Blocks {
+		blocks, _ := config[block.Type].([]interface{})
+		config[block.Type] = append(blocks, evaluateBody(t, ctx, block.Body))
+	}
+	return config
+}
+
+func TestGenerateConfig(t *testing.T) {
+	h := newTestHarness(t)
+	h.provider = Provider()
+	requireNoErrors(t, "configure provider",
+		h.provider.Configure(h.ctx, terraform.NewResourceConfigRaw(scopedProviderConfig(h, true, true))))
+
+	zone := h.apply("tenablesc_scan_zone", nil, map[string]interface{}{
+		"name":       "Datacenter",
+		"zone_cidrs": []interface{}{"10.0.0.0/8"},
+	})
+	org := h.apply("tenablesc_organization", nil, map[string]interface{}{
+		"name":           "tenant",
+		"zone_selection": "selectable",
+		"scan_zone_ids":  []interface{}{zone.ID},
+	})
+	h.apply("tenablesc_organization_scan_zone_association", nil, map[string]interface{}{
+		"organization_id": org.ID,
+		"scan_zone_ids":   []interface{}{zone.ID},
+	})
+	repo := h.apply("tenablesc_repository", nil, map[string]interface{}{
+		"name":     "internal",
+		"ip_range": "10.0.0.0/8",
+	})
+	h.apply("tenablesc_repository_organization_association", nil, map[string]interface{}{
+		"repository_id": repo.ID,
+		"organization":  []interface{}{map[string]interface{}{"organization_id": org.ID}},
+	})
+	// repositories without organizations don't need an association.
+	h.apply("tenablesc_repository", nil, map[string]interface{}{
+		"name":     "unassigned",
+		"ip_range": "192.168.0.0/16",
+	})
+
+	web := h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "web servers",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.1"},
+	})
+	db := h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "db",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.2"},
+	})
+	h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":        "web or db",
+		"type":        "combination",
+		"combination": web.ID + " OR " + db.ID,
+	})
+	policy := h.apply("tenablesc_scan_policy", nil, map[string]interface{}{
+		"name":               "${basic}",
+		"policy_template_id": "1",
+		"preferences":        map[string]interface{}{"max_checks": "5"},
+	})
+	for _, name := range []string{"weekly", "weekly"} {
+		h.apply("tenablesc_scan", nil, map[string]interface{}{
+			"name":          name,
+			"repository_id": repo.ID,
+			"policy_id":     policy.ID,
+			"asset_ids":     []interface{}{web.ID, db.ID},
+		})
+	}
+
+	var out bytes.Buffer
+	requireNoErrors(t, "generate configuration", generateConfig(h.ctx, h.provider, &out, nil))
+	generated := out.String()
+
+	for _, expected := range []string{
+		`resource "tenablesc_organization" "tenant" {`,
+		"scan_zone_ids  = [tenablesc_scan_zone.datacenter.id]",
+		`resource "tenablesc_organization_scan_zone_association" "tenant" {`,
+		"organization_id = tenablesc_organization.tenant.id\n  scan_zone_ids   = [tenablesc_scan_zone.datacenter.id]",
+		`resource "tenablesc_repository_organization_association" "internal" {`,
+		"organization_id = tenablesc_organization.tenant.id",
+		"repository_id = tenablesc_repository.internal.id",
+		`combination = "${tenablesc_asset.web_servers.id} OR ${tenablesc_asset.db.id}"`,
+		`name               = "$${basic}"`,
+		`resource "tenablesc_scan" "weekly_`,
+		"asset_ids     = [tenablesc_asset.web_servers.id, tenablesc_asset.db.id]",
+		"policy_id     = tenablesc_scan_policy.basic.id",
+	} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("expected generated configuration to contain %q:\n%s", expected, generated)
+		}
+	}
+	if strings.Contains(generated, `"tenablesc_repository_organization_association" "unassigned"`) {
+		t.Errorf("expected no association for a repository without organizations:\n%s", generated)
+	}
+
+	resources, imports := parseGeneratedConfig(t, out.Bytes())
+	if len(resources) != len(imports) || len(resources) != 12 {
+		t.Fatalf("expected 12 resources, each with an import block, got %d and %d:\n%s", len(resources), len(imports), generated)
+	}
+
+	// adopting the objects with the configuration generated must not plan any changes, but for the
+	// scan zones of organizations, which are configured on them but not read back.
+	for i, r := range resources {
+		if r.labels[0] != imports[i].labels[0] || r.labels[1] != imports[i].labels[1] {
+			t.Fatalf("expected import block of %s.%s to precede it", r.labels[0], r.labels[1])
+		}
+		state := h.importState(r.labels[0], imports[i].config["id"].(string))
+		if r.labels[0] != "tenablesc_organization" {
+			h.requireEmptyPlan(r.labels[0], state, r.config)
+			continue
+		}
+		for k := range h.plan(r.labels[0], state, r.config).Attributes {
+			if !strings.HasPrefix(k, "scan_zone_ids") {
+				t.Errorf("expected adopting organization %s to only store its scan zones, got a change to %s", r.labels[1], k)
+			}
+		}
+	}
+}
+
+func TestGenerateConfigSkipsTypesOfOtherScope(t *testing.T) {
+	h := newTestHarness(t)
+	h.provider = Provider()
+	requireNoErrors(t, "configure provider",
+		h.provider.Configure(h.ctx, terraform.NewResourceConfigRaw(scopedProviderConfig(h, false, true))))
+
+	h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "web",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.1"},
+	})
+
+	var out bytes.Buffer
+	diags := generateConfig(h.ctx, h.provider, &out, []string{"tenablesc_asset", "tenablesc_scan_zone"})
+	if diags.HasError() || len(diags) != 1 || diags[0].Summary != "Skipped tenablesc_scan_zone" {
+		t.Fatalf("expected only a warning about skipping scan zones, got %+v", diags)
+	}
+	if !strings.Contains(out.String(), `resource "tenablesc_asset" "web" {`) {
+		t.Errorf("expected assets to be generated:\n%s", out.String())
+	}
+
+	if diags := generateConfig(h.ctx, h.provider, &out, []string{"tenablesc_scan_launch"}); !diags.HasError() {
+		t.Error("expected generating an unsupported resource type to fail")
+	}
+}
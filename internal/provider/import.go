@@ -0,0 +1,61 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// importByName returns an importer accepting, besides IDs, import IDs of the form <prefix>:<name>.
+// The name is resolved to an ID through list, which must find exactly one object of that name;
+// identify returns the ID and name of an object listed.
+func importByName[T any](prefix, objectType string, list func(*tenablesc.Client) ([]T, error), identify func(T) (string, string)) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if !strings.HasPrefix(d.Id(), prefix+":") {
+			return schema.ImportStatePassthroughContext(ctx, d, m)
+		}
+		name := strings.TrimPrefix(d.Id(), prefix+":")
+
+		objects, err := list(m.(*tenablesc.Client))
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s %q: %w", objectType, name, err)
+		}
+
+		var ids []string
+		for _, object := range objects {
+			if id, objectName := identify(object); objectName == name {
+				ids = append(ids, id)
+			}
+		}
+
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("no %s is named %q", objectType, name)
+		case 1:
+			logDebug(ctx, "resolved import by name", map[string]interface{}{"name": name, logFieldObjectID: ids[0]})
+			d.SetId(ids[0])
+			return []*schema.ResourceData{d}, nil
+		default:
+			sort.Strings(ids)
+			return nil, fmt.Errorf("%d objects of type %s are named %q, with IDs %s; import by ID instead", len(ids), objectType, name, strings.Join(ids, ", "))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestImportByName(t *testing.T) {
+	h := newTestHarness(t)
+
+	repo := h.apply("tenablesc_repository", nil, map[string]interface{}{
+		"name":     "internal",
+		"ip_range": "10.0.0.0/8",
+	})
+	audit := h.apply("tenablesc_auditfile", nil, map[string]interface{}{
+		"name":    "unix.audit",
+		"content": "<check_type:\"Unix\">\n</check_type>",
+	})
+
+	imported := h.importState("tenablesc_repository", "name:internal")
+	if imported.ID != repo.ID {
+		t.Fatalf("expected repository %s, got %s", repo.ID, imported.ID)
+	}
+	requireAttribute(t, imported, "ip_range", "10.0.0.0/8")
+
+	imported = h.importState("tenablesc_auditfile", "name:unix.audit")
+	if imported.ID != audit.ID {
+		t.Fatalf("expected audit file %s, got %s", audit.ID, imported.ID)
+	}
+
+	imported = h.importState("tenablesc_repository_organization_association", "repository:internal")
+	if imported.ID != repo.ID {
+		t.Fatalf("expected association of repository %s, got %s", repo.ID, imported.ID)
+	}
+
+	// Plain IDs keep working, even those that would not resolve as names.
+	imported = h.importState("tenablesc_repository", repo.ID)
+	if imported.ID != repo.ID {
+		t.Fatalf("expected repository %s, got %s", repo.ID, imported.ID)
+	}
+}
+
+func TestImportByNameRequiresUniqueMatch(t *testing.T) {
+	h := newTestHarness(t)
+
+	first := h.server.Seed(fakesc.EndpointAsset, fakesc.Object{"name": "web", "type": "static"})
+	second := h.server.Seed(fakesc.EndpointAsset, fakesc.Object{"name": "web", "type": "static"})
+
+	for id, expected := range map[string][]string{
+		"name:db":  {`no asset is named "db"`},
+		"name:web": {`2 objects of type asset are named "web"`, first, second},
+	} {
+		_, err := h.provider.ImportState(h.ctx, &terraform.InstanceInfo{Type: "tenablesc_asset"}, id)
+		if err == nil {
+			t.Fatalf("expected importing %s to fail", id)
+		}
+		for _, s := range expected {
+			if !strings.Contains(err.Error(), s) {
+				t.Errorf("expected error importing %s to mention %q, got: %v", id, s, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The provider logs to its own tflog subsystem, at the level of TF_LOG_PROVIDER unless TENABLESC_LOG sets another.
+const (
+	logSubsystem = "tenablesc"
+	logLevelEnv  = "TENABLESC_LOG"
+)
+
+// Fields of the logs of operations and of the SC requests they make.
+const (
+	logFieldResourceType = "tenablesc_resource_type"
+	logFieldObjectID     = "tenablesc_object_id"
+	logFieldMethod       = "tenablesc_method"
+	logFieldEndpoint     = "tenablesc_endpoint"
+	logFieldStatusCode   = "tenablesc_status_code"
+	logFieldDuration     = "tenablesc_duration_ms"
+)
+
+// sensitiveLogKeys are masked in log fields, along with every attribute of the provider's schemas marked sensitive.
+var sensitiveLogKeys = []string{
+	"access_key",
+	"secret_key",
+	"password",
+	"private_key",
+	"passphrase",
+	"token",
+	"x-apikey",
+	"x-securitycenter",
+	"authorization",
+}
+
+// sensitiveLogPatterns are masked in log messages and fields, for secrets embedded in a larger value
+// like an error message or a request body. JSON fields are masked wherever their key contains a secret's
+// name, like SC's escalationPassword and communityString.
+var sensitiveLogPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(access|secret)key=[^;\s"]*`),
+	regexp.MustCompile(`(?i)"[^"]*(password|passphrase|privatekey|secret|token|accesskey|community)[^"]*"\s*:\s*"[^"]*"`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// withLogging has the configuration and every operation of p log to the tenablesc subsystem, with the type of
+// resource and the SC object operated on as fields, and secrets masked.
+func withLogging(p *schema.Provider) *schema.Provider {
+	keys := append(sensitiveAttributes(p), sensitiveLogKeys...)
+
+	configure := p.ConfigureContextFunc
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configure(newLogContext(ctx, keys), d)
+	}
+
+	for resourceType, r := range p.ResourcesMap {
+		withResourceLogging(resourceType, keys, r)
+	}
+	for resourceType, r := range p.DataSourcesMap {
+		withResourceLogging(resourceType, keys, r)
+	}
+
+	return p
+}
+
+func withResourceLogging(resourceType string, keys []string, r *schema.Resource) {
+	r.CreateContext = logged(resourceType, "create", keys, r.CreateContext)
+	r.ReadContext = logged(resourceType, "read", keys, r.ReadContext)
+	r.UpdateContext = logged(resourceType, "update", keys, r.UpdateContext)
+	r.DeleteContext = logged(resourceType, "delete", keys, r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importer := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			return importer(operationLogContext(ctx, resourceType, d.Id(), keys), d, m)
+		}
+	}
+}
+
+// logged logs the outcome of the operation f, and has it log with the resource type and object ID as fields.
+func logged[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](resourceType, operation string, keys []string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx = operationLogContext(ctx, resourceType, d.Id(), keys)
+		start := time.Now()
+
+		diags := f(ctx, d, m)
+
+		fields := map[string]interface{}{
+			logFieldObjectID: d.Id(),
+			logFieldDuration: time.Since(start).Milliseconds(),
+		}
+		if diags.HasError() {
+			logDebug(ctx, operation+" failed", fields)
+		} else {
+			logDebug(ctx, operation+" finished", fields)
+		}
+		return diags
+	}
+}
+
+func operationLogContext(ctx context.Context, resourceType, id string, keys []string) context.Context {
+	ctx = newLogContext(ctx, keys)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, logFieldResourceType, resourceType)
+	if id != "" {
+		ctx = tflog.SubsystemSetField(ctx, logSubsystem, logFieldObjectID, id)
+	}
+	return ctx
+}
+
+// newLogContext sets up the tenablesc subsystem in ctx, masking the values of fields named any of keys.
+func newLogContext(ctx context.Context, keys []string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem,
+		tflog.WithLevelFromEnv(logLevelEnv),
+		// report the caller of the helpers below as the location.
+		tflog.WithAdditionalLocationOffset(1),
+	)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, keys...)
+	ctx = tflog.SubsystemMaskLogRegexes(ctx, logSubsystem, sensitiveLogPatterns...)
+	return ctx
+}
+
+// sensitiveAttributes returns the names of the attributes marked sensitive in any schema of p.
+func sensitiveAttributes(p *schema.Provider) []string {
+	names := map[string]bool{}
+
+	var collect func(map[string]*schema.Schema)
+	collect = func(s map[string]*schema.Schema) {
+		for name, attribute := range s {
+			if attribute.Sensitive {
+				names[name] = true
+			}
+			if elem, ok := attribute.Elem.(*schema.Resource); ok {
+				collect(elem.Schema)
+			}
+		}
+	}
+
+	collect(p.Schema)
+	for _, r := range p.ResourcesMap {
+		collect(r.Schema)
+	}
+	for _, r := range p.DataSourcesMap {
+		collect(r.Schema)
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// logRequest is the RequestLogger of the provider's clients.
+func logRequest(ctx context.Context, method, endpoint string, statusCode int, duration time.Duration, err error) {
+	fields := map[string]interface{}{
+		logFieldMethod:     method,
+		logFieldEndpoint:   endpoint,
+		logFieldStatusCode: statusCode,
+		logFieldDuration:   duration.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		logDebug(ctx, "SC request failed", fields)
+		return
+	}
+	logDebug(ctx, "SC request", fields)
+}
+
+func logTrace(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemTrace(ctx, logSubsystem, msg, fields...)
+}
+
+func logDebug(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemDebug(ctx, logSubsystem, msg, fields...)
+}
+
+func logInfo(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemInfo(ctx, logSubsystem, msg, fields...)
+}
+
+func logError(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	tflog.SubsystemError(ctx, logSubsystem, msg, fields...)
+}
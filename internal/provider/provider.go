@@ -16,38 +16,59 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 // Provider implement the SC Provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return withLogging(withWriteLimits(&schema.Provider{
 		ConfigureContextFunc: configureProvider,
 		ResourcesMap: map[string]*schema.Resource{
-			"tenablesc_accept_risk":                         ResourceAcceptRisk(),
-			"tenablesc_asset":                               ResourceAsset(),
-			"tenablesc_auditfile":                           ResourceAuditFile(),
-			"tenablesc_organization":                        ResourceOrganization(),
-			"tenablesc_recast_risk":                         ResourceRecastRisk(),
-			"tenablesc_repository":                          ResourceRepository(),
-			"tenablesc_scan_policy":                         ResourceScanPolicy(),
-			"tenablesc_scan":                                ResourceScan(),
-			"tenablesc_scan_zone":                           ResourceScanZone(),
-			"tenablesc_repository_organization_association": ResourceRepositoryOrganizationAssociation(),
-			"tenablesc_organization_scan_zone_association":  ResourceOrganizationScanZoneAssociation(),
-			"tenablesc_role":                                ResourceRole(),
+			"tenablesc_accept_risk":                         withScope(scopeOrganization, ResourceAcceptRisk()),
+			"tenablesc_accept_risk_rules":                   withScope(scopeOrganization, ResourceAcceptRiskRules()),
+			"tenablesc_agent_scan":                          withScope(scopeOrganization, ResourceAgentScan()),
+			"tenablesc_asset":                               withScope(scopeOrganization, ResourceAsset()),
+			"tenablesc_auditfile":                           withScope(scopeAny, ResourceAuditFile()),
+			"tenablesc_credential":                          withScope(scopeOrganization, ResourceCredential()),
+			"tenablesc_group":                               withScope(scopeOrganization, ResourceGroup()),
+			"tenablesc_organization":                        withScope(scopeAdmin, ResourceOrganization()),
+			"tenablesc_recast_risk":                         withScope(scopeOrganization, ResourceRecastRisk()),
+			"tenablesc_recast_risk_rules":                   withScope(scopeOrganization, ResourceRecastRiskRules()),
+			"tenablesc_repository":                          withScope(scopeAdmin, ResourceRepository()),
+			"tenablesc_scan_policy":                         withScope(scopeOrganization, ResourceScanPolicy()),
+			"tenablesc_scan":                                withScope(scopeOrganization, ResourceScan()),
+			"tenablesc_scan_launch":                         withScope(scopeOrganization, ResourceScanLaunch()),
+			"tenablesc_scan_zone":                           withScope(scopeAdmin, ResourceScanZone()),
+			"tenablesc_repository_organization_association": withScope(scopeAdmin, ResourceRepositoryOrganizationAssociation()),
+			"tenablesc_organization_scan_zone_association":  withScope(scopeAdmin, ResourceOrganizationScanZoneAssociation()),
+			"tenablesc_role":                                withScope(scopeOrganization, ResourceRole()),
+			"tenablesc_user":                                withScopeFunc(userScope, ResourceUser()),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"tenablesc_plugin":               DataSourcePlugin(),
-			"tenablesc_repository":           DataSourceRepository(),
-			"tenablesc_repositories":         DataSourceRepositories(),
-			"tenablesc_asset":                DataSourceAsset(),
-			"tenablesc_assets":               DataSourceAssets(),
-			"tenablesc_scan_policy_template": DataSourceScanPolicyTemplate(),
-			"tenablesc_credential":           DataSourceCredential(),
+			"tenablesc_agent_groups":         withScope(scopeOrganization, DataSourceAgentGroups()),
+			"tenablesc_plugin":               withScope(scopeAny, DataSourcePlugin()),
+			"tenablesc_repository":           withScope(scopeAny, DataSourceRepository()),
+			"tenablesc_repositories":         withScope(scopeAny, DataSourceRepositories()),
+			"tenablesc_asset":                withScope(scopeOrganization, DataSourceAsset()),
+			"tenablesc_assets":               withScope(scopeOrganization, DataSourceAssets()),
+			"tenablesc_scan_policy":          withScope(scopeOrganization, DataSourceScanPolicy()),
+			"tenablesc_scan_policies":        withScope(scopeOrganization, DataSourceScanPolicies()),
+			"tenablesc_scan_policy_template": withScope(scopeAny, DataSourceScanPolicyTemplate()),
+			"tenablesc_vulnerabilities":      withScope(scopeOrganization, DataSourceVulnerabilities()),
+			"tenablesc_credential":           withScope(scopeAny, DataSourceCredential()),
+			"tenablesc_group":                withScope(scopeOrganization, DataSourceGroup()),
 		},
 		Schema: map[string]*schema.Schema{
 			"uri": {
@@ -58,33 +79,405 @@ func Provider() *schema.Provider {
 			},
 			"access_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("TENABLESC_ACCESS_KEY", nil),
-				Description: "SC Access Key to use",
+				Description: "SC Access Key to use. Conflicts with `username`.",
 			},
 			"secret_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("TENABLESC_SECRET_KEY", nil),
-				Description: "SC Secret Key to use",
+				Description: "SC Secret Key to use. Conflicts with `password`.",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TENABLESC_USERNAME", nil),
+				Description: "SC username to log in with instead of API keys. A session is opened at configuration and released when the provider exits.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("TENABLESC_PASSWORD", nil),
+				Description: "SC password to log in with instead of API keys.",
+			},
+			"admin": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: scopedCredentialsSchema()},
+				Description: "Administrator (org=0) credentials. Resources and data sources requiring them use these, and everything else the top-level or `organization` credentials.",
+			},
+			"organization": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        &schema.Resource{Schema: scopedCredentialsSchema()},
+				Description: "Organization credentials. Resources and data sources requiring them use these, and everything else the top-level or `admin` credentials.",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA certificates to trust for the SC server certificate, in addition to the system trust store.",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a file of PEM encoded CA certificates to trust for the SC server certificate, in addition to the system trust store.",
+			},
+			"client_cert_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key_pem"},
+				Description:  "PEM encoded client certificate to present to SC, for instances behind mutual TLS.",
+			},
+			"client_key_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert_pem"},
+				Description:  "PEM encoded private key of `client_cert_pem`.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip verification of the SC server certificate. Only meant for testing; a warning is raised when set.",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "How many times to retry a request failing with a transient error: a 5xx or 429 response, or an SC error listed in `retryable_error_codes` or `retryable_error_messages`. Only reads and deletes are retried unless `retry_writes` is set.",
+			},
+			"retry_writes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also retry creates and updates failing with a transient error. SC may have committed a write before failing, so retrying it can create duplicate objects.",
+			},
+			"min_retry_wait": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "1s",
+				ValidateDiagFunc: validateDuration,
+				Description:      "Initial wait between retries, as a duration like `500ms`. The wait doubles, with jitter, on each attempt.",
+			},
+			"max_retry_wait": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "30s",
+				ValidateDiagFunc: validateDuration,
+				Description:      "Longest wait between retries, as a duration like `1m`. Also caps waits requested by SC with a `Retry-After` header.",
+			},
+			"request_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "0s",
+				ValidateDiagFunc: validateDuration,
+				Description:      "Timeout for a single request attempt, as a duration like `2m`. `0s` means no timeout.",
+			},
+			"retryable_error_codes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "SC error codes to treat as transient and retry.",
+			},
+			"retryable_error_messages": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Substrings of SC error messages to treat as transient and retry, in addition to `database is locked`.",
+			},
+			"max_concurrent_writes": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validateNonNegative,
+				Description:      "Most creates, updates and deletes to make to SC at once, to spare SC the database lock contention of Terraform's parallelism. `0` means no limit. Reads are not limited.",
+			},
+			"serialized_object_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateSerializableObjectType,
+				},
+				Description: fmt.Sprintf("SC object types to write one at a time, across every resource writing to them. For example, `repository` serializes the writes of `tenablesc_repository` and `tenablesc_repository_organization_association`. One of: `%s`.", strings.Join(serializableObjectTypes(), "`, `")),
+			},
+			"cache_data_source_lists": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Have data sources share the lists of SC objects they look objects up in, so each list is queried once per run instead of once per data source. Writing to an object type drops its cached lists.",
 			},
 		},
+	}))
+}
+
+// scopedCredentialsSchema is the schema of the admin and organization credential blocks.
+func scopedCredentialsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"access_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "SC Access Key to use. Conflicts with `username`.",
+		},
+		"secret_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "SC Secret Key to use. Conflicts with `password`.",
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "SC username to log in with instead of API keys.",
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "SC password to log in with instead of API keys.",
+		},
+	}
+}
+
+// credentials are a set of credentials configured for the provider, either the top-level ones or a scope's block.
+type credentials struct {
+	accessKey string
+	secretKey string
+	username  string
+	password  string
+}
+
+func credentialsFrom(get func(string) interface{}) credentials {
+	return credentials{
+		accessKey: get("access_key").(string),
+		secretKey: get("secret_key").(string),
+		username:  get("username").(string),
+		password:  get("password").(string),
 	}
 }
 
+func (c credentials) empty() bool {
+	return c == credentials{}
+}
+
+// authenticate has client use the credentials, returning the user they belong to.
+func (c credentials) authenticate(ctx context.Context, client *tenablesc.Client) (*tenablesc.CurrentUser, error) {
+	switch {
+	case (c.accessKey != "" || c.secretKey != "") && (c.username != "" || c.password != ""):
+		return nil, fmt.Errorf("access_key/secret_key and username/password are mutually exclusive; configure only one pair")
+	case c.accessKey != "" && c.secretKey != "":
+		client.SetAPIKey(c.accessKey, c.secretKey)
+	case c.username != "" && c.password != "":
+		if err := client.Login(c.username, c.password); err != nil {
+			return nil, err
+		}
+		trackSession(client)
+	default:
+		return nil, fmt.Errorf("either both access_key and secret_key or both username and password must be configured")
+	}
+
+	return client.WithContext(ctx).GetCurrentUser()
+}
+
 func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	accessKey := d.Get("access_key").(string)
-	secretKey := d.Get("secret_key").(string)
 	scURI := d.Get("uri").(string)
 
-	client := tenablesc.NewClient(scURI).SetAPIKey(accessKey, secretKey)
+	var diags diag.Diagnostics
 
-	currentUser, err := client.GetCurrentUser()
+	tlsConfig, err := buildTLSConfig(ctx, d)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	if tlsConfig.InsecureSkipVerify {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS certificate verification is disabled",
+			Detail:   "insecure_skip_verify is set, so the identity of the SC server is not verified. Configure ca_cert_pem or ca_cert_file instead outside of testing.",
+		})
+	}
+
+	// durations are already validated.
+	minRetryWait, _ := time.ParseDuration(d.Get("min_retry_wait").(string))
+	maxRetryWait, _ := time.ParseDuration(d.Get("max_retry_wait").(string))
+	requestTimeout, _ := time.ParseDuration(d.Get("request_timeout").(string))
+
+	var retryableCodes []int
+	for _, code := range d.Get("retryable_error_codes").([]interface{}) {
+		retryableCodes = append(retryableCodes, code.(int))
+	}
+	var retryableMessages []string
+	for _, message := range d.Get("retryable_error_messages").([]interface{}) {
+		retryableMessages = append(retryableMessages, message.(string))
+	}
 
-	Logf(logDebug, "Configured provider with user %+v", *currentUser)
+	httpTrace, traceDiags := withHTTPTrace()
+	diags = append(diags, traceDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var listCache *tenablesc.ListCache
+	if d.Get("cache_data_source_lists").(bool) {
+		listCache = tenablesc.NewListCache()
+	}
+
+	newClient := func() *tenablesc.Client {
+		return httpTrace(tenablesc.NewClient(scURI)).
+			SetListCache(listCache).
+			SetTLSClientConfig(tlsConfig).
+			SetRetries(d.Get("max_retries").(int), minRetryWait, maxRetryWait).
+			SetRetryWrites(d.Get("retry_writes").(bool)).
+			SetRequestTimeout(requestTimeout).
+			AddRetryableErrors(retryableCodes, retryableMessages).
+			SetRequestLogger(logRequest)
+	}
+
+	var serializedObjectTypes []string
+	for _, objectType := range d.Get("serialized_object_types").(*schema.Set).List() {
+		serializedObjectTypes = append(serializedObjectTypes, objectType.(string))
+	}
+
+	clients := &providerClients{
+		writes: newWriteLimits(d.Get("max_concurrent_writes").(int), serializedObjectTypes),
+	}
 
-	return client, nil
+	if creds := credentialsFrom(d.Get); !creds.empty() {
+		client := newClient()
+		currentUser, err := creds.authenticate(ctx, client)
+		if err != nil {
+			return nil, append(diags, diag.FromErr(err)...)
+		}
+
+		logDebug(ctx, "configured provider", map[string]interface{}{"username": currentUser.Username, "organization_id": currentUser.Organization.ID})
+
+		clients.defaultClient = client
+	}
+
+	for _, scope := range []credentialScope{scopeAdmin, scopeOrganization} {
+		block := d.Get(scope.String()).([]interface{})
+		if len(block) == 0 || block[0] == nil {
+			continue
+		}
+		blockValues := block[0].(map[string]interface{})
+		creds := credentialsFrom(func(k string) interface{} { return blockValues[k] })
+
+		client := newClient()
+		currentUser, err := creds.authenticate(ctx, client)
+		if err == nil {
+			err = scope.check(currentUser)
+		}
+		if err != nil {
+			return nil, append(diags, diag.Errorf("%s credentials: %s", scope, err)...)
+		}
+
+		logDebug(ctx, "configured provider "+scope.String()+" credentials", map[string]interface{}{"username": currentUser.Username, "organization_id": currentUser.Organization.ID})
+
+		if scope == scopeAdmin {
+			clients.admin = client
+		} else {
+			clients.organization = client
+		}
+	}
+
+	if clients.defaultClient == nil && clients.admin == nil && clients.organization == nil {
+		return nil, append(diags, diag.Errorf("no credentials configured; configure access_key and secret_key, username and password, or an admin or organization block")...)
+	}
+
+	return clients, diags
+}
+
+func validateNonNegative(i interface{}, path cty.Path) diag.Diagnostics {
+	if i.(int) < 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("expected a number of at least 0, got %d", i),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+func validateDuration(i interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := time.ParseDuration(i.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid duration %q: %s", i, err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// buildTLSConfig returns the TLS configuration to connect to SC with; without any TLS attributes set that is Go's default.
+func buildTLSConfig(ctx context.Context, d *schema.ResourceData) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+
+	caCertPEM := d.Get("ca_cert_pem").(string)
+	if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+		content, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+		}
+		caCertPEM = string(content)
+	}
+
+	if caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			logInfo(ctx, "unable to load system certificate pool, trusting only the configured CA certificates", map[string]interface{}{"error": err.Error()})
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in CA certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	if clientCertPEM := d.Get("client_cert_pem").(string); clientCertPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCertPEM), []byte(d.Get("client_key_pem").(string)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// Clients logged in with username/password hold a session on SC until logged out.
+// The plugin SDK has no shutdown hook for providers, so sessions are tracked here
+// and released by CloseSessions once the plugin server stops.
+var (
+	sessionsMu sync.Mutex
+	sessions   []*tenablesc.Client
+)
+
+func trackSession(client *tenablesc.Client) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	sessions = append(sessions, client)
+}
+
+// CloseSessions logs out every session opened by configured providers.
+func CloseSessions() {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	for _, client := range sessions {
+		if err := client.Logout(); err != nil {
+			// the plugin server is gone, and the tflog logger with it.
+			log.Printf("[ERROR] failed to release session: %v", err)
+		}
+	}
+	sessions = nil
 }
@@ -0,0 +1,519 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// testHarness drives resources through the same Diff/Apply/Refresh/Import calls terraform makes,
+// against a fake SC, so lifecycles can be tested without a terraform binary or a live instance.
+// It skips terraform's own plan and apply consistency checks; TestResourceAssetUnitTest runs those
+// through resource.UnitTest against the same fake.
+type testHarness struct {
+	t        *testing.T
+	ctx      context.Context
+	server   *fakesc.Server
+	provider *schema.Provider
+}
+
+func newTestHarness(t *testing.T) *testHarness {
+	t.Helper()
+
+	server := fakesc.NewServer()
+	t.Cleanup(server.Close)
+
+	h := &testHarness{
+		t:        t,
+		ctx:      context.Background(),
+		server:   server,
+		provider: Provider(),
+	}
+
+	diags := h.provider.Configure(h.ctx, terraform.NewResourceConfigRaw(h.providerConfig()))
+	requireNoErrors(t, "configure provider", diags)
+
+	return h
+}
+
+func (h *testHarness) providerConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"uri":        h.server.URI(),
+		"access_key": fakesc.DefaultAccessKey,
+		"secret_key": fakesc.DefaultSecretKey,
+	}
+}
+
+func (h *testHarness) resource(resourceType string) *schema.Resource {
+	h.t.Helper()
+
+	r, ok := h.provider.ResourcesMap[resourceType]
+	if !ok {
+		h.t.Fatalf("resource type %s is not registered", resourceType)
+	}
+	return r
+}
+
+// plan returns the diff terraform would compute for config against state.
+func (h *testHarness) plan(resourceType string, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+	h.t.Helper()
+
+	r := h.resource(resourceType)
+	c := terraform.NewResourceConfigRaw(config)
+
+	requireNoErrors(h.t, "validate "+resourceType, r.Validate(c))
+
+	diff, err := r.Diff(h.ctx, state, c, h.provider.Meta())
+	if err != nil {
+		h.t.Fatalf("plan %s: %v", resourceType, err)
+	}
+	return diff
+}
+
+// apply plans and applies config on top of state, returning the refreshed result.
+func (h *testHarness) apply(resourceType string, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceState {
+	h.t.Helper()
+
+	diff := h.plan(resourceType, state, config)
+	if diff == nil || diff.Empty() {
+		return state
+	}
+
+	newState, diags := h.resource(resourceType).Apply(h.ctx, state, diff, h.provider.Meta())
+	requireNoErrors(h.t, "apply "+resourceType, diags)
+	if newState == nil || newState.ID == "" {
+		h.t.Fatalf("apply %s returned no state", resourceType)
+	}
+
+	return h.refresh(resourceType, newState)
+}
+
+// applyExpectError plans and applies config, returning the diagnostics instead of failing on errors.
+func (h *testHarness) applyExpectError(resourceType string, state *terraform.InstanceState, config map[string]interface{}) diag.Diagnostics {
+	h.t.Helper()
+
+	diff := h.plan(resourceType, state, config)
+	if diff == nil || diff.Empty() {
+		h.t.Fatalf("apply %s: expected changes, got none", resourceType)
+	}
+
+	_, diags := h.resource(resourceType).Apply(h.ctx, state, diff, h.provider.Meta())
+	if !diags.HasError() {
+		h.t.Fatalf("apply %s: expected an error, got none", resourceType)
+	}
+	return diags
+}
+
+func (h *testHarness) refresh(resourceType string, state *terraform.InstanceState) *terraform.InstanceState {
+	h.t.Helper()
+
+	newState, diags := h.resource(resourceType).RefreshWithoutUpgrade(h.ctx, state, h.provider.Meta())
+	requireNoErrors(h.t, "refresh "+resourceType, diags)
+	return newState
+}
+
+// importState imports id and refreshes the result, as `terraform import` does.
+func (h *testHarness) importState(resourceType, id string) *terraform.InstanceState {
+	h.t.Helper()
+
+	states, err := h.provider.ImportState(h.ctx, &terraform.InstanceInfo{Type: resourceType}, id)
+	if err != nil {
+		h.t.Fatalf("import %s %s: %v", resourceType, id, err)
+	}
+	if len(states) != 1 {
+		h.t.Fatalf("import %s %s: expected 1 state, got %d", resourceType, id, len(states))
+	}
+
+	state := h.refresh(resourceType, states[0])
+	if state == nil {
+		h.t.Fatalf("import %s %s: resource vanished during refresh", resourceType, id)
+	}
+	return state
+}
+
+func (h *testHarness) destroy(resourceType string, state *terraform.InstanceState) {
+	h.t.Helper()
+
+	diff := &terraform.InstanceDiff{Destroy: true}
+	_, diags := h.resource(resourceType).Apply(h.ctx, state, diff, h.provider.Meta())
+	requireNoErrors(h.t, "destroy "+resourceType, diags)
+}
+
+// requireEmptyPlan fails if config would produce changes against state.
+func (h *testHarness) requireEmptyPlan(resourceType string, state *terraform.InstanceState, config map[string]interface{}) {
+	h.t.Helper()
+
+	if diff := h.plan(resourceType, state, config); diff != nil && !diff.Empty() {
+		h.t.Fatalf("expected empty plan for %s, got %s", resourceType, diff.GoString())
+	}
+}
+
+// readDataSource reads a data source and returns its resulting state.
+func (h *testHarness) readDataSource(dataSourceType string, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	h.t.Helper()
+
+	r, ok := h.provider.DataSourcesMap[dataSourceType]
+	if !ok {
+		h.t.Fatalf("data source type %s is not registered", dataSourceType)
+	}
+
+	diff, err := r.Diff(h.ctx, nil, terraform.NewResourceConfigRaw(config), h.provider.Meta())
+	if err != nil {
+		h.t.Fatalf("plan data source %s: %v", dataSourceType, err)
+	}
+	if diff == nil {
+		diff = terraform.NewInstanceDiff()
+	}
+
+	return r.ReadDataApply(h.ctx, diff, h.provider.Meta())
+}
+
+func requireNoErrors(t *testing.T, action string, diags diag.Diagnostics) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			t.Fatalf("%s: %s: %s", action, d.Summary, d.Detail)
+		}
+	}
+}
+
+func requireAttribute(t *testing.T, state *terraform.InstanceState, key, expected string) {
+	t.Helper()
+
+	if state == nil {
+		t.Fatalf("expected attribute %s=%q, but state is empty", key, expected)
+	}
+	if actual := state.Attributes[key]; actual != expected {
+		t.Fatalf("expected attribute %s=%q, got %q", key, expected, actual)
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("provider failed internal validation: %v", err)
+	}
+}
+
+func TestConfigureProviderRejectsBadKeys(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := h.providerConfig()
+	config["secret_key"] = "wrong"
+
+	diags := Provider().Configure(h.ctx, terraform.NewResourceConfigRaw(config))
+	if !diags.HasError() {
+		t.Fatal("expected configure to fail with invalid API keys")
+	}
+}
+
+func TestConfigureProviderWithPassword(t *testing.T) {
+	h := newTestHarness(t)
+	h.server.SetLogin("break-glass", "hunter2")
+
+	config := map[string]interface{}{
+		"uri":      h.server.URI(),
+		"username": "break-glass",
+		"password": "hunter2",
+	}
+
+	p := Provider()
+	requireNoErrors(t, "configure provider", p.Configure(h.ctx, terraform.NewResourceConfigRaw(config)))
+	if sessions := h.server.Sessions(); sessions != 1 {
+		t.Fatalf("expected 1 open session, got %d", sessions)
+	}
+
+	// the session must be usable for everything else, not only the currentUser check at configuration.
+	h.server.Seed(fakesc.EndpointGroup, fakesc.Object{"name": "Full Access"})
+	groups, err := p.Meta().(*providerClients).defaultClient.GetAllGroups()
+	if err != nil {
+		t.Fatalf("query with session: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(groups))
+	}
+
+	CloseSessions()
+	if sessions := h.server.Sessions(); sessions != 0 {
+		t.Fatalf("expected session to be released, %d still open", sessions)
+	}
+}
+
+func TestConfigureProviderCredentialModes(t *testing.T) {
+	h := newTestHarness(t)
+
+	for name, config := range map[string]map[string]interface{}{
+		"bad password": {"username": fakesc.DefaultUsername, "password": "wrong"},
+		"mixed":        {"access_key": fakesc.DefaultAccessKey, "secret_key": fakesc.DefaultSecretKey, "username": fakesc.DefaultUsername, "password": fakesc.DefaultPassword},
+		"partial keys": {"access_key": fakesc.DefaultAccessKey},
+		"none":         {},
+	} {
+		config["uri"] = h.server.URI()
+		if diags := Provider().Configure(h.ctx, terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+			t.Errorf("%s: expected configure to fail", name)
+		}
+	}
+	if sessions := h.server.Sessions(); sessions != 0 {
+		t.Fatalf("expected no sessions to be opened, got %d", sessions)
+	}
+}
+
+func tlsProviderConfig(server *fakesc.Server, extra map[string]interface{}) *terraform.ResourceConfig {
+	config := map[string]interface{}{
+		"uri":        server.URI(),
+		"access_key": fakesc.DefaultAccessKey,
+		"secret_key": fakesc.DefaultSecretKey,
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+	return terraform.NewResourceConfigRaw(config)
+}
+
+func TestConfigureProviderTLS(t *testing.T) {
+	ctx := context.Background()
+	server := fakesc.NewTLSServer(nil)
+	t.Cleanup(server.Close)
+
+	if diags := Provider().Configure(ctx, tlsProviderConfig(server, nil)); !diags.HasError() {
+		t.Fatal("expected configure to fail against an untrusted certificate")
+	}
+
+	requireNoErrors(t, "configure with ca_cert_pem",
+		Provider().Configure(ctx, tlsProviderConfig(server, map[string]interface{}{"ca_cert_pem": server.CACertPEM()})))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(server.CACertPEM()), 0600); err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrors(t, "configure with ca_cert_file",
+		Provider().Configure(ctx, tlsProviderConfig(server, map[string]interface{}{"ca_cert_file": caFile})))
+
+	diags := Provider().Configure(ctx, tlsProviderConfig(server, map[string]interface{}{"insecure_skip_verify": true}))
+	requireNoErrors(t, "configure with insecure_skip_verify", diags)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning for insecure_skip_verify, got %+v", diags)
+	}
+
+	if diags := Provider().Configure(ctx, tlsProviderConfig(server, map[string]interface{}{"ca_cert_pem": "not a certificate"})); !diags.HasError() {
+		t.Fatal("expected configure to fail with an invalid ca_cert_pem")
+	}
+}
+
+func TestConfigureProviderClientCertificate(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server := fakesc.NewTLSServer(clientCAs)
+	t.Cleanup(server.Close)
+
+	if diags := Provider().Configure(ctx, tlsProviderConfig(server, map[string]interface{}{"ca_cert_pem": server.CACertPEM()})); !diags.HasError() {
+		t.Fatal("expected configure to fail without a client certificate")
+	}
+
+	requireNoErrors(t, "configure with client certificate",
+		Provider().Configure(ctx, tlsProviderConfig(server, map[string]interface{}{
+			"ca_cert_pem":     server.CACertPEM(),
+			"client_cert_pem": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+			"client_key_pem":  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+		})))
+}
+
+// configureClient configures a new provider against the harness' server with extra provider attributes.
+func (h *testHarness) configureClient(extra map[string]interface{}) *tenablesc.Client {
+	h.t.Helper()
+
+	config := h.providerConfig()
+	for k, v := range extra {
+		config[k] = v
+	}
+
+	p := Provider()
+	requireNoErrors(h.t, "configure provider", p.Configure(h.ctx, terraform.NewResourceConfigRaw(config)))
+	return p.Meta().(*providerClients).defaultClient
+}
+
+func TestProviderRetries(t *testing.T) {
+	h := newTestHarness(t)
+	client := h.configureClient(map[string]interface{}{
+		"max_retries":              2,
+		"min_retry_wait":           "1ms",
+		"max_retry_wait":           "10ms",
+		"retryable_error_codes":    []interface{}{999},
+		"retryable_error_messages": []interface{}{"try again later"},
+	})
+
+	for name, failure := range map[string][3]interface{}{
+		"server error":      {http.StatusBadGateway, 0, ""},
+		"database locked":   {http.StatusForbidden, 146, "database is locked"},
+		"extra code":        {http.StatusForbidden, 999, "busy"},
+		"extra message":     {http.StatusForbidden, 1, "please try again later"},
+		"too many requests": {http.StatusTooManyRequests, 0, ""},
+	} {
+		h.server.FailNext(2, failure[0].(int), failure[1].(int), failure[2].(string), "")
+		if _, err := client.GetCurrentUser(); err != nil {
+			t.Errorf("%s: expected request to succeed after retries: %v", name, err)
+		}
+	}
+
+	h.server.FailNext(3, http.StatusBadGateway, 0, "", "")
+	if _, err := client.GetCurrentUser(); err == nil {
+		t.Error("expected request to fail once retries are exhausted")
+	}
+
+	h.server.FailNext(1, http.StatusForbidden, 146, "Asset #12 not found", "")
+	if _, err := client.GetCurrentUser(); err == nil {
+		t.Error("expected a non-transient error not to be retried")
+	}
+}
+
+func TestProviderRetriesSkipWritesByDefault(t *testing.T) {
+	h := newTestHarness(t)
+	retrySettings := map[string]interface{}{
+		"min_retry_wait": "1ms",
+		"max_retry_wait": "10ms",
+	}
+
+	client := h.configureClient(retrySettings)
+	h.server.FailNext(1, http.StatusBadGateway, 0, "", "")
+	if _, err := client.CreateGroup(&tenablesc.Group{BaseInfo: tenablesc.BaseInfo{Name: "analysts"}}); err == nil {
+		t.Fatal("expected a failed create not to be retried by default")
+	}
+	if count := h.server.Count("group"); count != 0 {
+		t.Fatalf("expected no group to be created, got %d", count)
+	}
+
+	retrySettings["retry_writes"] = true
+	client = h.configureClient(retrySettings)
+	h.server.FailNext(1, http.StatusBadGateway, 0, "", "")
+	if _, err := client.CreateGroup(&tenablesc.Group{BaseInfo: tenablesc.BaseInfo{Name: "analysts"}}); err != nil {
+		t.Fatalf("expected create to succeed after retry with retry_writes set: %v", err)
+	}
+}
+
+func TestProviderRetriesHonorRetryAfter(t *testing.T) {
+	h := newTestHarness(t)
+	client := h.configureClient(map[string]interface{}{
+		"min_retry_wait": "1ms",
+		"max_retry_wait": "5s",
+	})
+
+	h.server.FailNext(1, http.StatusServiceUnavailable, 0, "", "1")
+
+	start := time.Now()
+	if _, err := client.GetCurrentUser(); err != nil {
+		t.Fatalf("expected request to succeed after retry: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected retry to wait for Retry-After, waited %s", elapsed)
+	}
+}
+
+func TestProviderRetrySettingsValidation(t *testing.T) {
+	for _, attr := range []string{"min_retry_wait", "max_retry_wait", "request_timeout"} {
+		if diags := Provider().Schema[attr].ValidateDiagFunc("ten seconds", cty.GetAttrPath(attr)); !diags.HasError() {
+			t.Errorf("expected %s to reject an invalid duration", attr)
+		}
+	}
+}
+
+func TestPermissionErrorsKeepResourcesInState(t *testing.T) {
+	h := newTestHarness(t)
+	r := h.resource("tenablesc_group")
+
+	state := h.apply("tenablesc_group", nil, map[string]interface{}{"name": "analysts"})
+
+	for name, message := range map[string]string{
+		"expired key": "Invalid login credentials",
+		"wrong role":  "Insufficient permissions to access Group #" + state.ID,
+	} {
+		h.server.FailNext(1, http.StatusForbidden, fakesc.ErrorCodeUnauthorized, message, "")
+		newState, diags := r.RefreshWithoutUpgrade(h.ctx, state, h.provider.Meta())
+		if !diags.HasError() {
+			t.Errorf("%s: expected refresh to fail", name)
+		}
+		if newState == nil || newState.ID != state.ID {
+			t.Errorf("%s: expected group to be kept in state, got %v", name, newState)
+		}
+	}
+
+	repository := h.apply("tenablesc_repository", nil, map[string]interface{}{"name": "internal", "ip_range": "10.0.0.0/8"})
+	h.server.FailNext(1, http.StatusForbidden, fakesc.ErrorCodeUnauthorized, "Insufficient permissions to access Repository #"+repository.ID, "")
+	newState, diags := h.resource("tenablesc_repository").RefreshWithoutUpgrade(h.ctx, repository, h.provider.Meta())
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, repository.ID) {
+		t.Errorf("expected refreshing the repository to fail naming it, got %v", diags)
+	}
+	if newState == nil || newState.ID != repository.ID {
+		t.Errorf("expected repository to be kept in state, got %v", newState)
+	}
+
+	h.server.FailNext(1, http.StatusForbidden, fakesc.ErrorCodeUnauthorized, "Invalid login credentials", "")
+	if _, diags := r.Apply(h.ctx, state, &terraform.InstanceDiff{Destroy: true}, h.provider.Meta()); !diags.HasError() {
+		t.Error("expected destroy to fail on a permission error")
+	}
+	if h.server.Get(fakesc.EndpointGroup, state.ID) == nil {
+		t.Fatal("expected group to still exist on SC")
+	}
+
+	h.server.Remove(fakesc.EndpointGroup, state.ID)
+	if newState := h.refresh("tenablesc_group", state); newState != nil {
+		t.Fatalf("expected group confirmed not found to be removed from state, got %v", newState)
+	}
+}
@@ -16,7 +16,9 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -24,7 +26,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 const timeLayout = "2006-01-02T15:04:00Z07:00"
@@ -37,6 +39,7 @@ func ResourceAcceptRisk() *schema.Resource {
 		ReadContext:   resourceAcceptRiskRead,
 		UpdateContext: resourceAcceptRiskUpdate,
 		DeleteContext: resourceAcceptRiskDelete,
+		CustomizeDiff: customizeAcceptRiskDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -86,10 +89,40 @@ func ResourceAcceptRisk() *schema.Resource {
 				Type:                  schema.TypeString,
 				Description:           descriptionAcceptRiskExpiration,
 				Optional:              true,
-				DiffSuppressFunc:      DiffSuppressParsedTimes,
+				DiffSuppressFunc:      suppressAcceptRiskExpiration,
 				DiffSuppressOnRefresh: true,
 				Default:               "-1",
 				ValidateDiagFunc:      validateAcceptRiskExpirationInFuture,
+				ConflictsWith:         []string{"expires_in"},
+			},
+			"expires_in": {
+				Type:             schema.TypeString,
+				Description:      descriptionAcceptRiskExpiresIn,
+				Optional:         true,
+				ValidateDiagFunc: validateExpiryDuration,
+				ConflictsWith:    []string{"expiration"},
+			},
+			"renew_before": {
+				Type:             schema.TypeString,
+				Description:      descriptionAcceptRiskRenewBefore,
+				Optional:         true,
+				ValidateDiagFunc: validateExpiryDuration,
+				RequiredWith:     []string{"expires_in"},
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Description: descriptionAcceptRiskExpiresAt,
+				Computed:    true,
+			},
+			"renewal": {
+				Type:        schema.TypeString,
+				Description: descriptionAcceptRiskRenewal,
+				Computed:    true,
+			},
+			"lapsed": {
+				Type:        schema.TypeBool,
+				Description: descriptionAcceptRiskLapsed,
+				Computed:    true,
 			},
 			"comments": {
 				Type:        schema.TypeString,
@@ -102,21 +135,37 @@ func ResourceAcceptRisk() *schema.Resource {
 }
 
 func resourceAcceptRiskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
+	rule, err := buildAcceptRiskInput(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	acceptRisks, err := sc.CreateAcceptRiskRule(rule)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(acceptRisks.ID)
+
+	return resourceAcceptRiskRead(ctx, d, m)
+}
+
+func buildAcceptRiskInput(d *schema.ResourceData) (*tenablesc.AcceptRiskRule, error) {
 	repositoryID := d.Get("repository_id").(string)
 	pluginID := d.Get("plugin_id").(string)
 	hostType := d.Get("host_type").(string)
 	hostValue := d.Get("host_value").(string)
 	port := d.Get("port").(string)
 	expiration := d.Get("expiration").(string)
-	comments := d.Get("comments").(string)
+	comments := withAcceptRiskRenewal(d.Get("comments").(string), d.Get("renewal").(string))
 
 	protocol, err := getRecastAcceptRiskProtocolID(d.Get("protocol").(string))
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to get protocol id: %w", err))
+		return nil, fmt.Errorf("failed to get protocol id: %w", err)
 	}
 
 	rule := &tenablesc.AcceptRiskRule{
@@ -130,46 +179,61 @@ func resourceAcceptRiskCreate(ctx context.Context, d *schema.ResourceData, m int
 		Repository: &tenablesc.BaseInfo{ID: tenablesc.ProbablyString(repositoryID)},
 		HostValue:  hostValue,
 	}
-	if strings.Compare(expiration, "-1") != 0 {
+	if expiresIn := d.Get("expires_in").(string); expiresIn != "" {
+		lifetime, err := parseExpiryDuration(expiresIn)
+		if err != nil {
+			return nil, err
+		}
+		expiry := time.Now().Add(lifetime)
+		// updates for other reasons keep the rule's expiration; only renewing or changing expires_in moves it.
+		if expiresAt, _ := d.GetChange("expires_at"); d.Id() != "" && !d.HasChange("expires_in") && !acceptRiskRenewing(d, expiresAt.(string)) {
+			if expiry, err = time.Parse(timeLayout, expiresAt.(string)); err != nil {
+				return nil, err
+			}
+		}
+		rule.Expires = fmt.Sprintf("%d", expiry.Unix())
+	} else if strings.Compare(expiration, "-1") != 0 {
 		expirationTime, parseErr := time.Parse(timeLayout, expiration)
 		if parseErr != nil {
-			return diag.FromErr(parseErr)
+			return nil, parseErr
 		}
 
 		et := fmt.Sprintf("%d", expirationTime.Unix())
 		rule.Expires = et
 	}
 
-	acceptRisks, err := sc.CreateAcceptRiskRule(rule)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	Logf(logDebug, "response: %+v", acceptRisks)
-
-	d.SetId(acceptRisks.ID)
-
-	return resourceAcceptRiskRead(ctx, d, m)
+	return rule, nil
 }
 
 func resourceAcceptRiskRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	acceptRisk, err := sc.GetAcceptRiskRule(d.Id())
 	if err != nil {
-		diags = append(diags, handleNotFoundError(d, err)...)
+		// SC deletes rules once they expire; keep those in state so the plan shows them lapsing.
+		if expiredAt, ok := acceptRiskExpired(d); ok && errors.As(err, &tenablesc.NotFoundError{}) {
+			d.Set("lapsed", true)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("accept risk rule %s lapsed", d.Id()),
+				Detail:   fmt.Sprintf("The rule expired at %s and SC deleted it, so the findings it accepted are no longer accepted. It is planned to be replaced.", expiredAt.Format(timeLayout)),
+			})
+			return
+		}
+		diags = append(diags, handleNotFoundError(ctx, d, err)...)
 		return
 	}
 
-	Logf(logDebug, "response: %+v", acceptRisk)
-
 	d.Set("host_type", acceptRisk.HostType)
 	d.Set("host_value", acceptRisk.HostValue)
 
 	d.Set("port", acceptRisk.Port)
 	d.Set("protocol", acceptRisk.Protocol)
-	d.Set("comments", acceptRisk.Comments)
+	comments, renewal := splitAcceptRiskRenewal(acceptRisk.Comments)
+	d.Set("comments", comments)
+	d.Set("renewal", renewal)
+	d.Set("lapsed", false)
 	d.Set("repository_id", acceptRisk.Repository.ID)
 	d.SetId(acceptRisk.ID)
 
@@ -186,6 +250,7 @@ func resourceAcceptRiskRead(ctx context.Context, d *schema.ResourceData, m inter
 		expTime := time.Unix(expInt, 0)
 		expString := expTime.Format(timeLayout)
 		d.Set("expiration", expString)
+		d.Set("expires_at", expString)
 		if expTime.Before(time.Now()) {
 			diags = append(diags,
 				diag.Diagnostic{
@@ -196,35 +261,48 @@ func resourceAcceptRiskRead(ctx context.Context, d *schema.ResourceData, m inter
 		}
 	} else {
 		d.Set("expiration", "-1")
+		d.Set("expires_at", "-1")
 	}
 
 	return
 }
 
 func resourceAcceptRiskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
-	deleteError := resourceAcceptRiskDelete(ctx, d, m)
-	if deleteError != nil {
-		return deleteError
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	rule, err := buildAcceptRiskInput(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	createError := resourceAcceptRiskCreate(ctx, d, m)
-	if createError != nil {
-		return createError
+	if expiresAt, _ := d.GetChange("expires_at"); acceptRiskRenewing(d, expiresAt.(string)) {
+		user, err := sc.GetCurrentUser()
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to look up who is renewing the rule: %w", err))
+		}
+		renewal := fmt.Sprintf("Reapproved by %s on %s", user.Username, time.Now().UTC().Format("2006-01-02"))
+		rule.Comments = withAcceptRiskRenewal(d.Get("comments").(string), renewal)
 	}
 
-	return resourceAcceptRiskRead(ctx, d, m)
+	return replaceRiskRule(ctx, d, m, "accept risk", func() (string, error) {
+		created, err := sc.CreateAcceptRiskRule(rule)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	}, sc.DeleteAcceptRiskRule, resourceAcceptRiskRead)
 }
 
 func resourceAcceptRiskDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	acceptID := d.Id()
 
 	err := sc.DeleteAcceptRiskRule(acceptID)
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
@@ -260,3 +338,143 @@ func validateAcceptRiskExpirationInFuture(expiration any, path cty.Path) (diags
 	}
 	return
 }
+
+// suppressAcceptRiskExpiration leaves expiration at its default when expires_in manages the expiration instead.
+func suppressAcceptRiskExpiration(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("expires_in").(string) != "" || DiffSuppressParsedTimes(k, old, new, d)
+}
+
+// customizeAcceptRiskDiff plans replacing lapsed rules, and renewing rules with expires_in once they
+// are within renew_before of expiring.
+func customizeAcceptRiskDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("lapsed").(bool) {
+		if err := d.SetNew("lapsed", false); err != nil {
+			return err
+		}
+		return d.ForceNew("lapsed")
+	}
+
+	expiresIn := d.Get("expires_in").(string)
+	if expiresIn == "" {
+		return nil
+	}
+	lifetime, err := parseExpiryDuration(expiresIn)
+	if err != nil {
+		return err
+	}
+	renewBefore, err := acceptRiskRenewBefore(d.Get("renew_before").(string))
+	if err != nil {
+		return err
+	}
+	if renewBefore >= lifetime {
+		return fmt.Errorf("renew_before %s must be shorter than expires_in %s, or rules would be renewed on every plan", d.Get("renew_before"), expiresIn)
+	}
+
+	// other updates keep expires_at, see buildAcceptRiskInput.
+	if d.Id() != "" && (d.HasChange("expires_in") || acceptRiskRenewalDue(d.Get("expires_at").(string), renewBefore)) {
+		return d.SetNewComputed("expires_at")
+	}
+	return nil
+}
+
+// acceptRiskRenewing returns whether an update of d renews the rule, rather than changing expires_in.
+func acceptRiskRenewing(d *schema.ResourceData, expiresAt string) bool {
+	if d.Get("expires_in").(string) == "" || d.HasChange("expires_in") {
+		return false
+	}
+	renewBefore, err := acceptRiskRenewBefore(d.Get("renew_before").(string))
+	return err == nil && acceptRiskRenewalDue(expiresAt, renewBefore)
+}
+
+func acceptRiskRenewBefore(renewBefore string) (time.Duration, error) {
+	if renewBefore != "" {
+		return parseExpiryDuration(renewBefore)
+	}
+	return 0, nil
+}
+
+// acceptRiskRenewalDue returns whether a rule expiring at expiresAt expires within renewBefore,
+// or does not expire at all though expires_in says it should.
+func acceptRiskRenewalDue(expiresAt string, renewBefore time.Duration) bool {
+	expiry, err := time.Parse(tenableTime, expiresAt)
+	if err != nil {
+		return true
+	}
+	return time.Until(expiry) <= renewBefore
+}
+
+// acceptRiskExpired returns when the rule in state expired, if it has.
+func acceptRiskExpired(d *schema.ResourceData) (time.Time, bool) {
+	expiresAt := d.Get("expires_at").(string)
+	if expiresAt == "" {
+		// state from before expires_at was tracked.
+		expiresAt = d.Get("expiration").(string)
+	}
+	expiry, err := time.Parse(tenableTime, expiresAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiry, expiry.Before(time.Now())
+}
+
+// acceptRiskRenewalPattern matches the note renewing a rule leaves at the end of its comments in SC.
+var acceptRiskRenewalPattern = regexp.MustCompile(`\n?\[(Reapproved by [^\]\n]+ on \d{4}-\d{2}-\d{2})\]$`)
+
+func withAcceptRiskRenewal(comments, renewal string) string {
+	if renewal == "" {
+		return comments
+	}
+	return comments + "\n[" + renewal + "]"
+}
+
+// splitAcceptRiskRenewal separates the comments of a rule in SC into those configured and its renewal note.
+func splitAcceptRiskRenewal(comments string) (string, string) {
+	match := acceptRiskRenewalPattern.FindStringSubmatchIndex(comments)
+	if match == nil {
+		return comments, ""
+	}
+	return comments[:match[0]], comments[match[2]:match[3]]
+}
+
+// parseExpiryDuration parses a number of days like '90d', of weeks like '2w', or a duration like '36h'.
+func parseExpiryDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, errors.New("duration must not be empty")
+	}
+
+	var duration time.Duration
+	switch unit := s[len(s)-1:]; unit {
+	case "d", "w":
+		count, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		duration = time.Duration(count) * 24 * time.Hour
+		if unit == "w" {
+			duration *= 7
+		}
+	default:
+		var err error
+		if duration, err = time.ParseDuration(s); err != nil {
+			return 0, err
+		}
+	}
+
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", s)
+	}
+	return duration, nil
+}
+
+func validateExpiryDuration(i interface{}, path cty.Path) diag.Diagnostics {
+	if s := i.(string); s != "" {
+		if _, err := parseExpiryDuration(s); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: path,
+			}}
+		}
+	}
+	return nil
+}
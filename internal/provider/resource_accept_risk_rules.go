@@ -0,0 +1,159 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// ResourceAcceptRiskRules Initialize the Accept Risk Rules Resource
+func ResourceAcceptRiskRules() *schema.Resource {
+	return riskRuleSet{
+		kind: "accept risk",
+		rule: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"plugin_id": {
+					Type:        schema.TypeString,
+					Description: descriptionPluginID,
+					Required:    true,
+				},
+				"host_type": {
+					Type:        schema.TypeString,
+					Description: descriptionRiskRuleHostType,
+					Optional:    true,
+					Default:     "all",
+				},
+				"host_value": {
+					Type:        schema.TypeString,
+					Description: descriptionRiskRuleHostValue,
+					Optional:    true,
+					Default:     "",
+				},
+				"port": {
+					Type:        schema.TypeString,
+					Description: descriptionPort,
+					Optional:    true,
+					Default:     "any",
+				},
+				"protocol": {
+					Type:             schema.TypeString,
+					Description:      descriptionProtocol,
+					Optional:         true,
+					Default:          "any",
+					ValidateDiagFunc: validateRecastAcceptRiskProtocol,
+				},
+				"expiration": {
+					Type:             schema.TypeString,
+					Description:      descriptionAcceptRiskRulesExpiration,
+					Optional:         true,
+					Default:          "-1",
+					ValidateDiagFunc: validateAcceptRiskRulesExpiration,
+				},
+				"comments": {
+					Type:        schema.TypeString,
+					Description: descriptionComments,
+					Optional:    true,
+					Default:     descriptionDefaultDescriptionValue,
+				},
+			},
+		},
+		list:   listAcceptRiskRules,
+		create: createAcceptRiskRulesMember,
+		remove: func(sc *tenablesc.Client, id string) error {
+			return sc.DeleteAcceptRiskRule(id)
+		},
+	}.resource(descriptionResourceAcceptRiskRules)
+}
+
+func listAcceptRiskRules(sc *tenablesc.Client) ([]riskRuleSetMember, error) {
+	acceptRisks, err := sc.GetAllAcceptRiskRules()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]riskRuleSetMember, 0, len(acceptRisks))
+	for _, acceptRisk := range acceptRisks {
+		if acceptRisk.Repository == nil || acceptRisk.Plugin == nil {
+			continue
+		}
+
+		expiration := "-1"
+		if expInt, err := strconv.ParseInt(acceptRisk.Expires, 10, 64); err == nil && expInt != -1 {
+			expiration = time.Unix(expInt, 0).Format(timeLayout)
+		}
+
+		members = append(members, riskRuleSetMember{
+			id:           acceptRisk.ID,
+			repositoryID: string(acceptRisk.Repository.ID),
+			rule: map[string]interface{}{
+				"plugin_id":  string(acceptRisk.Plugin.ID),
+				"host_type":  acceptRisk.HostType,
+				"host_value": riskRuleHostValue(acceptRisk.HostValue),
+				"port":       acceptRisk.Port,
+				"protocol":   riskRuleProtocolName(acceptRisk.Protocol),
+				"expiration": expiration,
+				"comments":   acceptRisk.Comments,
+			},
+		})
+	}
+	return members, nil
+}
+
+func createAcceptRiskRulesMember(sc *tenablesc.Client, repositoryID string, rule map[string]interface{}) (string, error) {
+	protocol, err := getRecastAcceptRiskProtocolID(rule["protocol"].(string))
+	if err != nil {
+		return "", fmt.Errorf("failed to get protocol id: %w", err)
+	}
+
+	input := &tenablesc.AcceptRiskRule{
+		AcceptRiskRuleBaseFields: tenablesc.AcceptRiskRuleBaseFields{
+			Plugin:   &tenablesc.BaseInfo{ID: tenablesc.ProbablyString(rule["plugin_id"].(string))},
+			HostType: rule["host_type"].(string),
+			Port:     rule["port"].(string),
+			Protocol: protocol,
+			Comments: rule["comments"].(string),
+		},
+		Repository: &tenablesc.BaseInfo{ID: tenablesc.ProbablyString(repositoryID)},
+		HostValue:  rule["host_value"].(string),
+	}
+	if expiration := rule["expiration"].(string); expiration != "-1" {
+		expirationTime, err := time.Parse(timeLayout, expiration)
+		if err != nil {
+			return "", err
+		}
+		input.Expires = fmt.Sprintf("%d", expirationTime.Unix())
+	}
+
+	created, err := sc.CreateAcceptRiskRule(input)
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// validateAcceptRiskRulesExpiration also accepts the '-1' default, for rules that don't expire.
+func validateAcceptRiskRulesExpiration(expiration any, path cty.Path) diag.Diagnostics {
+	if expiration == "-1" {
+		return nil
+	}
+	return validateAcceptRiskExpirationInFuture(expiration, path)
+}
@@ -0,0 +1,255 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceAcceptRiskLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	repositoryID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"})
+
+	config := map[string]interface{}{
+		"repository_id": repositoryID,
+		"plugin_id":     "19506",
+		"host_type":     "ip",
+		"host_value":    "10.0.0.1,10.0.0.2",
+	}
+
+	state := h.apply("tenablesc_accept_risk", nil, config)
+	requireAttribute(t, state, "repository_id", repositoryID)
+	requireAttribute(t, state, "expiration", "-1")
+	h.requireEmptyPlan("tenablesc_accept_risk", state, config)
+
+	config["expiration"] = time.Now().Add(24 * time.Hour).UTC().Format(timeLayout)
+	config["comments"] = "accepted until tomorrow"
+	state = h.apply("tenablesc_accept_risk", state, config)
+	requireAttribute(t, state, "comments", "accepted until tomorrow")
+	if h.server.Count(fakesc.EndpointAcceptRiskRule) != 1 {
+		t.Fatalf("expected exactly one accept risk rule, got %d", h.server.Count(fakesc.EndpointAcceptRiskRule))
+	}
+
+	imported := h.importState("tenablesc_accept_risk", state.ID)
+	requireAttribute(t, imported, "repository_id", repositoryID)
+	requireAttribute(t, imported, "comments", "accepted until tomorrow")
+
+	h.destroy("tenablesc_accept_risk", state)
+}
+
+func TestResourceRecastRiskLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	repositoryID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"})
+	assetID := h.server.Seed(fakesc.EndpointAsset, fakesc.Object{"name": "targets", "type": "static"})
+
+	config := map[string]interface{}{
+		"repository_id": repositoryID,
+		"plugin_id":     "19506",
+		"host_type":     "asset",
+		"host_value":    assetID,
+		"new_severity":  "1",
+	}
+
+	state := h.apply("tenablesc_recast_risk", nil, config)
+	requireAttribute(t, state, "new_severity", "1")
+	requireAttribute(t, state, "host_value", assetID)
+	h.requireEmptyPlan("tenablesc_recast_risk", state, config)
+
+	config["new_severity"] = "0"
+	state = h.apply("tenablesc_recast_risk", state, config)
+	requireAttribute(t, state, "new_severity", "0")
+
+	imported := h.importState("tenablesc_recast_risk", state.ID)
+	h.requireEmptyPlan("tenablesc_recast_risk", imported, config)
+
+	h.destroy("tenablesc_recast_risk", state)
+}
+
+func TestRiskRuleUpdateReplacesBeforeDeleting(t *testing.T) {
+	for _, resourceType := range []string{"tenablesc_accept_risk", "tenablesc_recast_risk"} {
+		t.Run(resourceType, func(t *testing.T) {
+			h := newTestHarness(t)
+
+			endpoint := fakesc.EndpointAcceptRiskRule
+			config := map[string]interface{}{
+				"repository_id": h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"}),
+				"plugin_id":     "19506",
+				"comments":      "first",
+			}
+			if resourceType == "tenablesc_recast_risk" {
+				endpoint = fakesc.EndpointRecastRiskRule
+				config["new_severity"] = "1"
+			}
+
+			state := h.apply(resourceType, nil, config)
+			original := state.ID
+
+			config["comments"] = "second"
+			before := len(h.server.Requests())
+			state = h.apply(resourceType, state, config)
+			if state.ID == original || h.server.Get(endpoint, original) != nil {
+				t.Fatalf("expected rule %s to be replaced, got %s", original, state.ID)
+			}
+			var writes []string
+			for _, r := range h.server.Requests()[before:] {
+				if !strings.HasPrefix(r, "GET") {
+					writes = append(writes, r)
+				}
+			}
+			if len(writes) != 2 || writes[0] != "POST /"+endpoint || writes[1] != "DELETE /"+endpoint+"/"+original {
+				t.Fatalf("expected the replacement to be created before the rule is deleted, got %v", writes)
+			}
+
+			// a failed create leaves the rule, and state, as they were.
+			config["comments"] = "third"
+			h.server.FailNextRequest(http.MethodPost, http.StatusBadRequest, fakesc.ErrorCodeInvalidInput, "Invalid rule")
+			failed, diags := h.resource(resourceType).Apply(h.ctx, state, h.plan(resourceType, state, config), h.provider.Meta())
+			if !diags.HasError() || !strings.Contains(diags[0].Detail, "Rule "+state.ID+" is unchanged") {
+				t.Fatalf("expected an error saying the rule is unchanged, got %+v", diags)
+			}
+			requireAttribute(t, failed, "id", state.ID)
+			requireAttribute(t, failed, "comments", "second")
+			if h.server.Count(endpoint) != 1 {
+				t.Fatalf("expected only the existing rule, got %d rules", h.server.Count(endpoint))
+			}
+
+			// a failed delete leaves both rules, with state tracking the replacement.
+			h.server.FailNextRequest(http.MethodDelete, http.StatusBadRequest, fakesc.ErrorCodeInvalidInput, "Rule is locked")
+			swapped, diags := h.resource(resourceType).Apply(h.ctx, state, h.plan(resourceType, state, config), h.provider.Meta())
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "rule "+state.ID+" was replaced by rule "+swapped.ID+", but could not be deleted") {
+				t.Fatalf("expected an error about the half-finished replacement, got %+v", diags)
+			}
+			requireAttribute(t, swapped, "comments", "third")
+			if swapped.ID == state.ID || h.server.Get(endpoint, swapped.ID) == nil || h.server.Get(endpoint, state.ID) == nil {
+				t.Fatalf("expected rules %s and %s to both exist, with state tracking the latter", state.ID, swapped.ID)
+			}
+			h.requireEmptyPlan(resourceType, h.refresh(resourceType, swapped), config)
+		})
+	}
+}
+
+func TestResourceAcceptRiskExpiresIn(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"repository_id": h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"}),
+		"plugin_id":     "19506",
+		"comments":      "false positive",
+		"expires_in":    "90d",
+		"renew_before":  "2w",
+	}
+
+	requireExpiresIn := func(state *terraform.InstanceState, lifetime time.Duration) {
+		t.Helper()
+		expiresAt, err := time.Parse(timeLayout, state.Attributes["expires_at"])
+		if err != nil || time.Until(expiresAt) < lifetime-2*time.Minute || time.Until(expiresAt) > lifetime {
+			t.Fatalf("expected the rule to expire in %s, got %q", lifetime, state.Attributes["expires_at"])
+		}
+	}
+
+	state := h.apply("tenablesc_accept_risk", nil, config)
+	requireExpiresIn(state, 90*24*time.Hour)
+	requireAttribute(t, state, "expiration", state.Attributes["expires_at"])
+	h.requireEmptyPlan("tenablesc_accept_risk", state, config)
+
+	// updates for other reasons keep the expiration.
+	h.server.Update(fakesc.EndpointAcceptRiskRule, state.ID, fakesc.Object{"expires": fmt.Sprint(time.Now().Add(60 * 24 * time.Hour).Unix())})
+	state = h.refresh("tenablesc_accept_risk", state)
+	expiresAt := state.Attributes["expires_at"]
+	config["comments"] = "accepted by security"
+	if diff := h.plan("tenablesc_accept_risk", state, config); diff == nil || diff.Attributes["expires_at"] != nil {
+		t.Fatalf("expected only the comments to change, got %v", diff)
+	}
+	state = h.apply("tenablesc_accept_risk", state, config)
+	requireAttribute(t, state, "expires_at", expiresAt)
+	requireAttribute(t, state, "renewal", "")
+	config["comments"] = "false positive"
+	state = h.apply("tenablesc_accept_risk", state, config)
+
+	// within renew_before of expiring, plans push the expiration forward.
+	h.server.Update(fakesc.EndpointAcceptRiskRule, state.ID, fakesc.Object{"expires": fmt.Sprint(time.Now().Add(10 * 24 * time.Hour).Unix())})
+	state = h.refresh("tenablesc_accept_risk", state)
+	if diff := h.plan("tenablesc_accept_risk", state, config); diff == nil || !diff.Attributes["expires_at"].NewComputed {
+		t.Fatalf("expected renewal to be planned, got %v", diff)
+	}
+	state = h.apply("tenablesc_accept_risk", state, config)
+	requireExpiresIn(state, 90*24*time.Hour)
+	renewal := "Reapproved by terraform on " + time.Now().UTC().Format("2006-01-02")
+	requireAttribute(t, state, "renewal", renewal)
+	requireAttribute(t, state, "comments", "false positive")
+	if comments := h.server.Get(fakesc.EndpointAcceptRiskRule, state.ID)["comments"]; comments != "false positive\n["+renewal+"]" {
+		t.Fatalf("expected the renewal to be recorded in the rule's comments, got %q", comments)
+	}
+	h.requireEmptyPlan("tenablesc_accept_risk", state, config)
+
+	// once SC deletes an expired rule, plans replace it.
+	h.server.Update(fakesc.EndpointAcceptRiskRule, state.ID, fakesc.Object{"expires": fmt.Sprint(time.Now().Add(-time.Hour).Unix())})
+	state = h.refresh("tenablesc_accept_risk", state)
+	h.server.Remove(fakesc.EndpointAcceptRiskRule, state.ID)
+	state = h.refresh("tenablesc_accept_risk", state)
+	requireAttribute(t, state, "lapsed", "true")
+	if diff := h.plan("tenablesc_accept_risk", state, config); diff == nil || !diff.RequiresNew() || !diff.Attributes["lapsed"].RequiresNew {
+		t.Fatalf("expected the lapsed rule to be replaced, got %v", diff)
+	}
+	lapsed := state.ID
+	state = h.apply("tenablesc_accept_risk", state, config)
+	if state.ID == lapsed || h.server.Count(fakesc.EndpointAcceptRiskRule) != 1 {
+		t.Fatalf("expected lapsed rule %s to be replaced, got %s", lapsed, state.ID)
+	}
+	requireAttribute(t, state, "lapsed", "false")
+	requireExpiresIn(state, 90*24*time.Hour)
+
+	// changing expires_in moves the expiration.
+	config["expires_in"] = "30d"
+	if diff := h.plan("tenablesc_accept_risk", state, config); diff == nil || !diff.Attributes["expires_at"].NewComputed {
+		t.Fatalf("expected a new expiration to be planned, got %v", diff)
+	}
+	state = h.apply("tenablesc_accept_risk", state, config)
+	requireExpiresIn(state, 30*24*time.Hour)
+
+	config["renew_before"] = "90d"
+	if _, err := h.resource("tenablesc_accept_risk").Diff(h.ctx, state, terraform.NewResourceConfigRaw(config), h.provider.Meta()); err == nil {
+		t.Fatal("expected renew_before as long as expires_in to be rejected")
+	}
+}
+
+func TestParseExpiryDuration(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"90d":  90 * 24 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"36h":  36 * time.Hour,
+		"1h5m": 65 * time.Minute,
+		"0d":   0,
+		"-1h":  0,
+		"d":    0,
+		"90x":  0,
+	} {
+		duration, err := parseExpiryDuration(s)
+		if expected == 0 && err == nil {
+			t.Errorf("%s: expected an error, got %s", s, duration)
+		} else if expected != 0 && (err != nil || duration != expected) {
+			t.Errorf("%s: expected %s, got %s (%v)", s, expected, duration, err)
+		}
+	}
+}
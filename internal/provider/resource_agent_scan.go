@@ -0,0 +1,212 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// ResourceAgentScan Initialize the Agent Scan Resource
+func ResourceAgentScan() *schema.Resource {
+	return &schema.Resource{
+		Description:   descriptionResourceAgentScan,
+		CreateContext: resourceAgentScanCreate,
+		ReadContext:   resourceAgentScanRead,
+		UpdateContext: resourceAgentScanUpdate,
+		DeleteContext: resourceAgentScanDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: descriptionAgentScanName,
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: descriptionAgentScanDescription,
+				Optional:    true,
+				Default:     descriptionDefaultDescriptionValue,
+			},
+			"repository_id": {
+				Type:        schema.TypeString,
+				Description: descriptionRepositoryID,
+				Required:    true,
+			},
+			"nessus_manager_id": {
+				Type:        schema.TypeString,
+				Description: descriptionNessusManagerID,
+				Required:    true,
+			},
+			"agent_group_ids": {
+				Type:        schema.TypeList,
+				Description: descriptionAgentScanAgentGroupIDs,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"scan_window": {
+				Type:        schema.TypeInt,
+				Description: descriptionAgentScanWindow,
+				Optional:    true,
+				Default:     60,
+			},
+			"email_on_launch": {
+				Type:        schema.TypeBool,
+				Description: descriptionEmailOnLaunch,
+				Optional:    true,
+				Default:     false,
+			},
+			"email_on_finish": {
+				Type:        schema.TypeBool,
+				Description: descriptionEmailOnFinish,
+				Optional:    true,
+				Default:     false,
+			},
+			"schedule_start": {
+				Type:        schema.TypeString,
+				Description: descriptionScheduleStart,
+				Optional:    true,
+				Default:     "",
+			},
+			"schedule_repeat_rule": {
+				Type:        schema.TypeString,
+				Description: descriptionScheduleRepeatRule,
+				Optional:    true,
+				Default:     "",
+			},
+		},
+	}
+}
+
+func resourceAgentScanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	scan, err := sc.CreateAgentScan(buildAgentScanInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(string(scan.ID))
+
+	return resourceAgentScanRead(ctx, d, m)
+}
+
+func resourceAgentScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	scan, err := sc.GetAgentScan(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	d.SetId(string(scan.ID))
+	d.Set("name", scan.Name)
+	d.Set("description", scan.Description)
+	d.Set("repository_id", scan.Repository.ID)
+	d.Set("nessus_manager_id", scan.NessusManager.ID)
+	d.Set("email_on_launch", scan.EmailOnLaunch.AsBool())
+	d.Set("email_on_finish", scan.EmailOnFinish.AsBool())
+
+	if scan.ScanWindow != "" {
+		scanWindow, err := strconv.Atoi(string(scan.ScanWindow))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("scan_window", scanWindow)
+	}
+
+	if scan.Schedule != nil {
+		d.Set("schedule_start", scan.Schedule.Start)
+		d.Set("schedule_repeat_rule", scan.Schedule.RepeatRule)
+	}
+
+	var agentGroupIDs []string
+	for _, group := range scan.AgentGroups {
+		agentGroupIDs = append(agentGroupIDs, string(group.ID))
+	}
+	d.Set("agent_group_ids", agentGroupIDs)
+
+	return nil
+}
+
+func resourceAgentScanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	_, err := sc.UpdateAgentScan(buildAgentScanInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAgentScanRead(ctx, d, m)
+}
+
+func resourceAgentScanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	err := sc.DeleteAgentScan(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	return nil
+}
+
+func buildAgentScanInput(d *schema.ResourceData) *tenablesc.AgentScan {
+	scheduleStart := d.Get("schedule_start").(string)
+
+	scanInput := &tenablesc.AgentScan{
+		BaseInfo: tenablesc.BaseInfo{
+			ID:          tenablesc.ProbablyString(d.Id()),
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		},
+		Repository:    tenablesc.BaseInfo{ID: tenablesc.ProbablyString(d.Get("repository_id").(string))},
+		NessusManager: tenablesc.BaseInfo{ID: tenablesc.ProbablyString(d.Get("nessus_manager_id").(string))},
+		ScanWindow:    tenablesc.ProbablyString(strconv.Itoa(d.Get("scan_window").(int))),
+		EmailOnLaunch: tenablesc.ToFakeBool(d.Get("email_on_launch").(bool)),
+		EmailOnFinish: tenablesc.ToFakeBool(d.Get("email_on_finish").(bool)),
+	}
+
+	for _, id := range bundleIDs(d.Get("agent_group_ids").([]interface{})) {
+		scanInput.AgentGroups = append(scanInput.AgentGroups, tenablesc.AgentGroup{BaseInfo: id})
+	}
+
+	scheduleType := "ical"
+	if scheduleStart == "" {
+		scheduleType = "template"
+	}
+
+	scanInput.Schedule = &tenablesc.ScanSchedule{
+		Type:       scheduleType,
+		Start:      scheduleStart,
+		RepeatRule: d.Get("schedule_repeat_rule").(string),
+	}
+
+	return scanInput
+}
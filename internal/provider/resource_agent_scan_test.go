@@ -0,0 +1,67 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceAgentScanLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	repositoryID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "agents", "type": "Local", "dataFormat": "agent"})
+	workstations := h.server.Seed(fakesc.AgentGroupsEndpoint("7"), fakesc.Object{"name": "workstations"})
+	servers := h.server.Seed(fakesc.AgentGroupsEndpoint("7"), fakesc.Object{"name": "servers"})
+
+	config := map[string]interface{}{
+		"name":              "agent scan",
+		"repository_id":     repositoryID,
+		"nessus_manager_id": "7",
+		"agent_group_ids":   []interface{}{workstations},
+	}
+
+	state := h.apply("tenablesc_agent_scan", nil, config)
+	requireAttribute(t, state, "nessus_manager_id", "7")
+	requireAttribute(t, state, "agent_group_ids.0", workstations)
+	requireAttribute(t, state, "scan_window", "60")
+	requireAttribute(t, state, "email_on_finish", "false")
+	h.requireEmptyPlan("tenablesc_agent_scan", state, config)
+
+	config["agent_group_ids"] = []interface{}{workstations, servers}
+	config["scan_window"] = 180
+	config["email_on_finish"] = true
+	config["schedule_start"] = "TZID=America/New_York:20230101T000000"
+	config["schedule_repeat_rule"] = "FREQ=WEEKLY;INTERVAL=1;BYDAY=SU"
+	state = h.apply("tenablesc_agent_scan", state, config)
+	requireAttribute(t, state, "agent_group_ids.#", "2")
+	requireAttribute(t, state, "scan_window", "180")
+	requireAttribute(t, state, "email_on_finish", "true")
+	h.requireEmptyPlan("tenablesc_agent_scan", state, config)
+
+	stored := h.server.Get(fakesc.EndpointAgentScan, state.ID)
+	if schedule := stored["schedule"].(map[string]interface{}); schedule["type"] != "ical" {
+		t.Fatalf("expected ical schedule, got %v", schedule)
+	}
+
+	imported := h.importState("tenablesc_agent_scan", state.ID)
+	h.requireEmptyPlan("tenablesc_agent_scan", imported, config)
+
+	h.destroy("tenablesc_agent_scan", state)
+	if h.server.Count(fakesc.EndpointAgentScan) != 0 {
+		t.Fatal("expected agent scan to be deleted")
+	}
+}
@@ -16,13 +16,17 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"unicode"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
-// ResourceAsset Initialize the Accept Risk Resource
+// ResourceAsset Initialize the Asset Resource
 func ResourceAsset() *schema.Resource {
 	return &schema.Resource{
 		Description:   descriptionResourceAsset,
@@ -32,9 +36,13 @@ func ResourceAsset() *schema.Resource {
 		DeleteContext: resourceAssetDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "asset", (*tenablesc.Client).GetAllAssets, func(a *tenablesc.Asset) (string, string) {
+				return string(a.ID), a.Name
+			}),
 		},
 
+		CustomizeDiff: validateAssetTypeFields,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -48,10 +56,11 @@ func ResourceAsset() *schema.Resource {
 				Default:     descriptionDefaultDescriptionValue,
 			},
 			"type": {
-				Type:        schema.TypeString,
-				Description: descriptionAssetType,
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      descriptionAssetType,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAssetType,
 			},
 			"values": {
 				Type:        schema.TypeSet,
@@ -59,45 +68,106 @@ func ResourceAsset() *schema.Resource {
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"rules": {
+				Type:        schema.TypeList,
+				Description: descriptionAssetRules,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: dynamicAssetRuleGroupSchema(dynamicAssetRuleGroupDepth),
+				},
+			},
+			"combination": {
+				Type:             schema.TypeString,
+				Description:      descriptionAssetCombination,
+				Optional:         true,
+				ValidateDiagFunc: validateAssetCombination,
+				DiffSuppressFunc: suppressEquivalentAssetCombination,
+			},
+			"ldap_query": {
+				Type:        schema.TypeList,
+				Description: descriptionAssetLDAPQuery,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ldap_id": {
+							Type:        schema.TypeString,
+							Description: descriptionAssetLDAPID,
+							Required:    true,
+						},
+						"search_base": {
+							Type:        schema.TypeString,
+							Description: descriptionAssetLDAPSearchBase,
+							Required:    true,
+						},
+						"search_string": {
+							Type:        schema.TypeString,
+							Description: descriptionAssetLDAPSearchString,
+							Required:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func resourceAssetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
-	response, err := sc.CreateAsset(buildAssetInput(d))
+	asset, err := buildAssetInput(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(string(response.ID))
+	response, err := sc.CreateAsset(asset)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	Logf(logDebug, "response: %+v", response)
+	d.SetId(string(response.ID))
 
 	return resourceAssetRead(ctx, d, m)
 }
 
 func resourceAssetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	assetResponse, err := sc.GetAsset(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", assetResponse)
-
 	d.Set("name", assetResponse.Name)
 	d.Set("description", assetResponse.Description)
 	d.Set("type", assetResponse.Type)
 	switch assetResponse.Type {
 	case "dnsname":
 		d.Set("values", assetResponse.DefinedDNSNames)
-	case "static":
+	case "static", "watchlist":
 		d.Set("values", assetResponse.DefinedIPs)
+	case "dynamic":
+		if assetResponse.Rules != nil {
+			d.Set("rules", []interface{}{flattenDynamicAssetRuleGroup(assetResponse.Rules)})
+		}
+	case "combination":
+		if assetResponse.Combinations != nil {
+			d.Set("combination", renderAssetCombination(assetResponse.Combinations, ""))
+		}
+	case "ldapquery":
+		if assetResponse.DefinedLDAPQuery != nil {
+			query := map[string]interface{}{
+				"search_base":   assetResponse.DefinedLDAPQuery.SearchBase,
+				"search_string": assetResponse.DefinedLDAPQuery.SearchString,
+			}
+			if assetResponse.LDAP != nil {
+				query["ldap_id"] = string(assetResponse.LDAP.ID)
+			}
+			d.Set("ldap_query", []interface{}{query})
+		}
 	}
 	d.SetId(string(assetResponse.ID))
 
@@ -105,10 +175,15 @@ func resourceAssetRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceAssetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
-	_, err := sc.UpdateAsset(buildAssetInput(d))
+	asset, err := buildAssetInput(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = sc.UpdateAsset(asset)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -117,20 +192,20 @@ func resourceAssetUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceAssetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	assetID := d.Id()
 
 	err := sc.DeleteAsset(assetID)
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
 }
 
-func buildAssetInput(d *schema.ResourceData) *tenablesc.Asset {
+func buildAssetInput(d *schema.ResourceData) (*tenablesc.Asset, error) {
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	assetType := d.Get("type").(string)
@@ -153,9 +228,382 @@ func buildAssetInput(d *schema.ResourceData) *tenablesc.Asset {
 	switch assetType {
 	case "dnsname":
 		assetInput.DefinedDNSNames = assetList
-	case "static":
+	case "static", "watchlist":
 		assetInput.DefinedIPs = assetList
+	case "dynamic":
+		if rules, ok := d.Get("rules").([]interface{}); ok && len(rules) > 0 && rules[0] != nil {
+			assetInput.Rules = expandDynamicAssetRuleGroup(rules[0].(map[string]interface{}))
+			// The root of the tree is untyped.
+			assetInput.Rules.Type = ""
+		}
+	case "combination":
+		// Only validated at plan time when known by then.
+		combination, err := parseAssetCombination(d.Get("combination").(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid combination %q: %w", d.Get("combination"), err)
+		}
+		assetInput.Combinations = combination
+	case "ldapquery":
+		if queries, ok := d.Get("ldap_query").([]interface{}); ok && len(queries) > 0 && queries[0] != nil {
+			query := queries[0].(map[string]interface{})
+			assetInput.DefinedLDAPQuery = &tenablesc.AssetLDAPQuery{
+				SearchBase:   query["search_base"].(string),
+				SearchString: query["search_string"].(string),
+			}
+			assetInput.LDAP = &tenablesc.BaseInfo{ID: tenablesc.ProbablyString(query["ldap_id"].(string))}
+		}
+	}
+
+	return assetInput, nil
+}
+
+// assetTypeFields maps each asset type to the attribute defining its content.
+var assetTypeFields = map[string]string{
+	"static":      "values",
+	"dnsname":     "values",
+	"watchlist":   "values",
+	"dynamic":     "rules",
+	"combination": "combination",
+	"ldapquery":   "ldap_query",
+}
+
+func validateAssetType(i interface{}, path cty.Path) diag.Diagnostics {
+	if _, ok := assetTypeFields[i.(string)]; !ok {
+		return diag.Errorf("%s is not a supported asset type. Valid types are static, dnsname, watchlist, dynamic, combination and ldapquery", i)
+	}
+	return nil
+}
+
+// validateAssetTypeFields ensures the attribute defining the asset's type is given, and no attribute of another type is.
+func validateAssetTypeFields(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	assetType := d.Get("type").(string)
+
+	for _, field := range []string{"rules", "combination", "ldap_query"} {
+		// GetOk reports unknown values, such as IDs of assets created in the same apply, as unset.
+		if !d.NewValueKnown(field) {
+			continue
+		}
+		_, set := d.GetOk(field)
+		if field == assetTypeFields[assetType] && !set {
+			return fmt.Errorf("%s assets require %s", assetType, field)
+		}
+		if field != assetTypeFields[assetType] && set {
+			return fmt.Errorf("%s is not valid for %s assets", field, assetType)
+		}
+	}
+
+	if _, set := d.GetOk("values"); set && d.NewValueKnown("values") && assetTypeFields[assetType] != "values" {
+		return fmt.Errorf("values is not valid for %s assets", assetType)
+	}
+
+	return nil
+}
+
+// Terraform schemas can't be recursive, so dynamic asset rule groups may only be nested this deep.
+const dynamicAssetRuleGroupDepth = 3
+
+func dynamicAssetRuleGroupSchema(depth int) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"operator": {
+			Type:             schema.TypeString,
+			Description:      descriptionAssetRuleGroupOperator,
+			Required:         true,
+			ValidateDiagFunc: validateDynamicAssetRuleGroupOperator,
+		},
+		"clause": {
+			Type:        schema.TypeList,
+			Description: descriptionAssetRuleClause,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"filter_name": {
+						Type:        schema.TypeString,
+						Description: descriptionAssetRuleFilterName,
+						Required:    true,
+					},
+					"operator": {
+						Type:        schema.TypeString,
+						Description: descriptionAssetRuleOperator,
+						Required:    true,
+					},
+					"value": {
+						Type:        schema.TypeString,
+						Description: descriptionAssetRuleValue,
+						Required:    true,
+					},
+					"plugin_id": {
+						Type:        schema.TypeString,
+						Description: descriptionAssetRulePluginID,
+						Optional:    true,
+					},
+				},
+			},
+		},
+	}
+
+	if depth > 0 {
+		s["group"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Description: descriptionAssetRuleGroup,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: dynamicAssetRuleGroupSchema(depth - 1),
+			},
+		}
+	}
+
+	return s
+}
+
+func validateDynamicAssetRuleGroupOperator(i interface{}, path cty.Path) diag.Diagnostics {
+	if operator := i.(string); operator != "all" && operator != "any" {
+		return diag.Errorf("%s is not a valid rule group operator. Valid operators are all and any", operator)
+	}
+	return nil
+}
+
+func expandDynamicAssetRuleGroup(group map[string]interface{}) *tenablesc.DynamicAssetRule {
+	rule := &tenablesc.DynamicAssetRule{
+		Type:     tenablesc.DynamicAssetRuleTypeGroup,
+		Operator: group["operator"].(string),
+	}
+
+	for _, c := range group["clause"].([]interface{}) {
+		clause := c.(map[string]interface{})
+		pluginID := clause["plugin_id"].(string)
+		if pluginID == "" {
+			pluginID = "-1"
+		}
+		rule.Children = append(rule.Children, &tenablesc.DynamicAssetRule{
+			Type:               tenablesc.DynamicAssetRuleTypeClause,
+			FilterName:         clause["filter_name"].(string),
+			Operator:           clause["operator"].(string),
+			Value:              tenablesc.DynamicAssetRuleValue(clause["value"].(string)),
+			PluginIDConstraint: tenablesc.ProbablyString(pluginID),
+		})
+	}
+
+	if groups, ok := group["group"].([]interface{}); ok {
+		for _, g := range groups {
+			rule.Children = append(rule.Children, expandDynamicAssetRuleGroup(g.(map[string]interface{})))
+		}
+	}
+
+	return rule
+}
+
+// flattenDynamicAssetRuleGroup splits a group's children into clauses and groups; order between the two
+// is not preserved, which doesn't change the meaning of 'all' and 'any'.
+func flattenDynamicAssetRuleGroup(rule *tenablesc.DynamicAssetRule) map[string]interface{} {
+	var clauses, groups []interface{}
+
+	for _, child := range rule.Children {
+		if child.Type == tenablesc.DynamicAssetRuleTypeGroup {
+			groups = append(groups, flattenDynamicAssetRuleGroup(child))
+			continue
+		}
+
+		pluginID := string(child.PluginIDConstraint)
+		if pluginID == "-1" {
+			pluginID = ""
+		}
+		clauses = append(clauses, map[string]interface{}{
+			"filter_name": child.FilterName,
+			"operator":    child.Operator,
+			"value":       string(child.Value),
+			"plugin_id":   pluginID,
+		})
+	}
+
+	group := map[string]interface{}{
+		"operator": rule.Operator,
+		"clause":   clauses,
+	}
+	if len(groups) > 0 {
+		group["group"] = groups
+	}
+
+	return group
+}
+
+var assetCombinationKeywords = map[string]string{
+	"AND": tenablesc.AssetCombinationIntersection,
+	"OR":  tenablesc.AssetCombinationUnion,
+	"NOT": tenablesc.AssetCombinationComplement,
+}
+
+// parseAssetCombination parses a boolean expression over asset IDs, like '12 AND (13 OR NOT 14)',
+// into the operand tree SC expects. NOT binds tightest, then AND, then OR.
+func parseAssetCombination(expr string) (*tenablesc.AssetCombination, error) {
+	p := &assetCombinationParser{tokens: tokenizeAssetCombination(expr)}
+
+	combination, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in asset combination", p.tokens[p.pos])
+	}
+
+	return combination, nil
+}
+
+func tokenizeAssetCombination(expr string) []string {
+	var tokens []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range expr {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+type assetCombinationParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *assetCombinationParser) peekKeyword() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return strings.ToUpper(p.tokens[p.pos])
+}
+
+func (p *assetCombinationParser) parseOr() (*tenablesc.AssetCombination, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword() == "OR" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &tenablesc.AssetCombination{Operator: tenablesc.AssetCombinationUnion, Operand1: left, Operand2: right}
+	}
+	return left, nil
+}
+
+func (p *assetCombinationParser) parseAnd() (*tenablesc.AssetCombination, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword() == "AND" {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &tenablesc.AssetCombination{Operator: tenablesc.AssetCombinationIntersection, Operand1: left, Operand2: right}
+	}
+	return left, nil
+}
+
+func (p *assetCombinationParser) parseNot() (*tenablesc.AssetCombination, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of asset combination")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch {
+	case strings.ToUpper(token) == "NOT":
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &tenablesc.AssetCombination{Operator: tenablesc.AssetCombinationComplement, Operand1: operand}, nil
+	case token == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis in asset combination")
+		}
+		p.pos++
+		return inner, nil
+	case token == ")" || assetCombinationKeywords[strings.ToUpper(token)] != "":
+		return nil, fmt.Errorf("unexpected %q in asset combination", token)
+	}
+
+	for _, r := range token {
+		if !unicode.IsDigit(r) {
+			return nil, fmt.Errorf("%q is not an asset ID", token)
+		}
 	}
+	return &tenablesc.AssetCombination{ID: tenablesc.ProbablyString(token)}, nil
+}
+
+// renderAssetCombination renders an operand tree as an expression, adding parentheses only where
+// an operand binds less tightly than its parent operator.
+func renderAssetCombination(c *tenablesc.AssetCombination, parent string) string {
+	if c == nil {
+		return ""
+	}
+
+	var rendered string
+	switch c.Operator {
+	case tenablesc.AssetCombinationUnion:
+		rendered = renderAssetCombination(c.Operand1, c.Operator) + " OR " + renderAssetCombination(c.Operand2, c.Operator)
+		if parent == tenablesc.AssetCombinationIntersection || parent == tenablesc.AssetCombinationComplement {
+			rendered = "(" + rendered + ")"
+		}
+	case tenablesc.AssetCombinationIntersection:
+		rendered = renderAssetCombination(c.Operand1, c.Operator) + " AND " + renderAssetCombination(c.Operand2, c.Operator)
+		if parent == tenablesc.AssetCombinationComplement {
+			rendered = "(" + rendered + ")"
+		}
+	case tenablesc.AssetCombinationComplement:
+		rendered = "NOT " + renderAssetCombination(c.Operand1, c.Operator)
+	default:
+		rendered = string(c.ID)
+	}
+
+	return rendered
+}
 
-	return assetInput
+func validateAssetCombination(i interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := parseAssetCombination(i.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid asset combination: %s", err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// suppressEquivalentAssetCombination ignores differences in whitespace, keyword case and redundant parentheses.
+func suppressEquivalentAssetCombination(k, old, new string, d *schema.ResourceData) bool {
+	oldCombination, err := parseAssetCombination(old)
+	if err != nil {
+		return false
+	}
+	newCombination, err := parseAssetCombination(new)
+	if err != nil {
+		return false
+	}
+	return renderAssetCombination(oldCombination, "") == renderAssetCombination(newCombination, "")
 }
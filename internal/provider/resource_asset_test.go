@@ -0,0 +1,319 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceAssetLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":   "test asset",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.1", "10.0.0.2"},
+	}
+
+	state := h.apply("tenablesc_asset", nil, config)
+	requireAttribute(t, state, "name", "test asset")
+	requireAttribute(t, state, "description", descriptionDefaultDescriptionValue)
+	requireAttribute(t, state, "values.#", "2")
+	h.requireEmptyPlan("tenablesc_asset", state, config)
+
+	config["name"] = "renamed asset"
+	config["values"] = []interface{}{"10.0.0.3"}
+	state = h.apply("tenablesc_asset", state, config)
+	requireAttribute(t, state, "name", "renamed asset")
+	requireAttribute(t, state, "values.#", "1")
+
+	imported := h.importState("tenablesc_asset", state.ID)
+	h.requireEmptyPlan("tenablesc_asset", imported, config)
+
+	h.destroy("tenablesc_asset", state)
+	if h.server.Count(fakesc.EndpointAsset) != 0 {
+		t.Fatal("expected asset to be deleted")
+	}
+}
+
+// TestResourceAssetUnitTest runs the lifecycle through terraform itself, so its plan and apply consistency
+// checks cover the provider too. It needs a terraform binary, on PATH or in TF_ACC_TERRAFORM_PATH.
+func TestResourceAssetUnitTest(t *testing.T) {
+	if _, err := exec.LookPath("terraform"); err != nil && os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		t.Skip("no terraform binary found; install terraform or set TF_ACC_TERRAFORM_PATH")
+	}
+
+	server := fakesc.NewServer()
+	t.Cleanup(server.Close)
+
+	config := func(name, value string) string {
+		return fmt.Sprintf(`
+provider "tenablesc" {
+  uri        = %q
+  access_key = %q
+  secret_key = %q
+}
+
+resource "tenablesc_asset" "test" {
+  name   = %q
+  type   = "static"
+  values = [%q]
+}
+`, server.URI(), fakesc.DefaultAccessKey, fakesc.DefaultSecretKey, name, value)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"tenablesc": func() (*schema.Provider, error) { return Provider(), nil },
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if count := server.Count(fakesc.EndpointAsset); count != 0 {
+				return fmt.Errorf("expected the asset to be deleted, %d remain", count)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("test asset", "10.0.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("tenablesc_asset.test", "name", "test asset"),
+					resource.TestCheckResourceAttr("tenablesc_asset.test", "values.#", "1"),
+				),
+			},
+			{
+				Config: config("renamed asset", "10.0.0.2"),
+				Check:  resource.TestCheckResourceAttr("tenablesc_asset.test", "name", "renamed asset"),
+			},
+			{
+				ResourceName:      "tenablesc_asset.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceAssetRemovedOutOfBand(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "dns asset",
+		"type":   "dnsname",
+		"values": []interface{}{"host.example.com"},
+	})
+
+	h.server.Remove(fakesc.EndpointAsset, state.ID)
+
+	if refreshed := h.refresh("tenablesc_asset", state); refreshed != nil {
+		t.Fatalf("expected asset to be dropped from state, got %v", refreshed)
+	}
+}
+
+func TestResourceAssetDynamic(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name": "log4j hosts",
+		"type": "dynamic",
+		"rules": []interface{}{map[string]interface{}{
+			"operator": "all",
+			"clause": []interface{}{
+				map[string]interface{}{"filter_name": "pluginText", "operator": "pcre", "value": "log4j-core-2\\.1[0-6]", "plugin_id": "113075"},
+			},
+			"group": []interface{}{map[string]interface{}{
+				"operator": "any",
+				"clause": []interface{}{
+					map[string]interface{}{"filter_name": "ip", "operator": "=", "value": "10.0.0.0/8"},
+					map[string]interface{}{"filter_name": "dns", "operator": "contains", "value": "corp"},
+				},
+			}},
+		}},
+	}
+
+	state := h.apply("tenablesc_asset", nil, config)
+	requireAttribute(t, state, "rules.0.operator", "all")
+	requireAttribute(t, state, "rules.0.clause.0.plugin_id", "113075")
+	requireAttribute(t, state, "rules.0.group.0.operator", "any")
+	requireAttribute(t, state, "rules.0.group.0.clause.#", "2")
+	requireAttribute(t, state, "rules.0.group.0.clause.1.plugin_id", "")
+	h.requireEmptyPlan("tenablesc_asset", state, config)
+
+	rules := h.server.Get(fakesc.EndpointAsset, state.ID)["typeFields"].(map[string]interface{})["rules"].(map[string]interface{})
+	if _, typed := rules["type"]; typed || rules["operator"] != "all" || len(rules["children"].([]interface{})) != 2 {
+		t.Fatalf("unexpected rule tree sent to SC: %v", rules)
+	}
+
+	// SC returns the value of filters referring to other objects as an object.
+	h.server.Update(fakesc.EndpointAsset, state.ID, fakesc.Object{"typeFields": fakesc.Object{"rules": fakesc.Object{
+		"operator": "any",
+		"children": []interface{}{
+			fakesc.Object{"type": "clause", "filterName": "asset", "operator": "=", "value": fakesc.Object{"id": "12", "name": "servers"}, "pluginIDConstraint": -1},
+		},
+	}}})
+	refreshed := h.refresh("tenablesc_asset", state)
+	requireAttribute(t, refreshed, "rules.0.clause.0.value", "12")
+	requireAttribute(t, refreshed, "rules.0.clause.0.plugin_id", "")
+	requireAttribute(t, refreshed, "rules.0.group.#", "0")
+
+	state = h.apply("tenablesc_asset", refreshed, config)
+	requireAttribute(t, state, "rules.0.group.0.clause.#", "2")
+	h.requireEmptyPlan("tenablesc_asset", state, config)
+}
+
+func TestResourceAssetCombination(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":        "servers outside the dmz",
+		"type":        "combination",
+		"combination": "(12 or 13) and not (14)",
+	}
+
+	state := h.apply("tenablesc_asset", nil, config)
+	requireAttribute(t, state, "combination", "(12 OR 13) AND NOT 14")
+	h.requireEmptyPlan("tenablesc_asset", state, config)
+
+	combination := h.server.Get(fakesc.EndpointAsset, state.ID)["typeFields"].(map[string]interface{})["combinations"].(map[string]interface{})
+	if combination["operator"] != "intersection" || combination["operand2"].(map[string]interface{})["operator"] != "complement" {
+		t.Fatalf("unexpected combination sent to SC: %v", combination)
+	}
+
+	config["combination"] = "(12 OR 13) AND NOT 15"
+	if diff := h.plan("tenablesc_asset", state, config); diff == nil || diff.Empty() {
+		t.Fatal("expected a changed combination to produce a diff")
+	}
+}
+
+func TestResourceAssetWatchlistAndLDAPQuery(t *testing.T) {
+	h := newTestHarness(t)
+
+	watchlist := map[string]interface{}{
+		"name":   "watched",
+		"type":   "watchlist",
+		"values": []interface{}{"192.168.0.1"},
+	}
+	state := h.apply("tenablesc_asset", nil, watchlist)
+	requireAttribute(t, state, "values.#", "1")
+	h.requireEmptyPlan("tenablesc_asset", state, watchlist)
+
+	ldap := map[string]interface{}{
+		"name": "domain controllers",
+		"type": "ldapquery",
+		"ldap_query": []interface{}{map[string]interface{}{
+			"ldap_id":       "2",
+			"search_base":   "OU=Domain Controllers,DC=example,DC=com",
+			"search_string": "name=*",
+		}},
+	}
+	state = h.apply("tenablesc_asset", nil, ldap)
+	requireAttribute(t, state, "ldap_query.0.ldap_id", "2")
+	requireAttribute(t, state, "ldap_query.0.search_string", "name=*")
+	h.requireEmptyPlan("tenablesc_asset", state, ldap)
+}
+
+func TestResourceAssetTypeFieldsValidation(t *testing.T) {
+	h := newTestHarness(t)
+
+	for name, config := range map[string]map[string]interface{}{
+		"missing rules":         {"name": "a", "type": "dynamic"},
+		"values on combination": {"name": "a", "type": "combination", "combination": "1", "values": []interface{}{"10.0.0.1"}},
+		"rules on static": {"name": "a", "type": "static", "rules": []interface{}{map[string]interface{}{
+			"operator": "all",
+		}}},
+	} {
+		r := h.resource("tenablesc_asset")
+		if _, err := r.Diff(h.ctx, nil, terraform.NewResourceConfigRaw(config), h.provider.Meta()); err == nil {
+			t.Errorf("%s: expected plan to fail", name)
+		}
+	}
+}
+
+// unknownValue is what terraform passes in raw configurations for values only known after apply.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceAssetTypeFieldsUnknownAtPlan(t *testing.T) {
+	h := newTestHarness(t)
+
+	for name, config := range map[string]map[string]interface{}{
+		"combination": {"name": "a", "type": "combination", "combination": unknownValue},
+		"ldap_query":  {"name": "a", "type": "ldapquery", "ldap_query": unknownValue},
+		"rules":       {"name": "a", "type": "dynamic", "rules": unknownValue},
+	} {
+		r := h.resource("tenablesc_asset")
+		if _, err := r.Diff(h.ctx, nil, terraform.NewResourceConfigRaw(config), h.provider.Meta()); err != nil {
+			t.Errorf("%s: expected an unknown %s to plan, got %v", name, name, err)
+		}
+	}
+}
+
+func TestResourceAssetInvalidCombinationKnownAtApply(t *testing.T) {
+	h := newTestHarness(t)
+	r := h.resource("tenablesc_asset")
+
+	// planning without validating stands in for a combination that was unknown at plan time,
+	// so the expression is only checked once known at apply.
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "a", "type": "combination", "combination": "1 AND"})
+	diff, err := r.Diff(h.ctx, nil, config, h.provider.Meta())
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, diags := r.Apply(h.ctx, nil, diff, h.provider.Meta())
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "invalid combination") {
+		t.Fatalf("expected an invalid combination to fail the apply, got %v", diags)
+	}
+	if state != nil && state.ID != "" {
+		t.Fatalf("expected no asset in state, got %v", state)
+	}
+	if count := h.server.Count(fakesc.EndpointAsset); count != 0 {
+		t.Fatalf("expected no asset to be created, got %d", count)
+	}
+}
+
+func TestParseAssetCombination(t *testing.T) {
+	for expr, expected := range map[string]string{
+		"12":                       "12",
+		"1 and 2 and 3":            "1 AND 2 AND 3",
+		"1 OR 2 AND 3":             "1 OR 2 AND 3",
+		"(1 OR 2) AND 3":           "(1 OR 2) AND 3",
+		"not (1 and 2)":            "NOT (1 AND 2)",
+		"NOT NOT 1":                "NOT NOT 1",
+		"((1)) or (not 2 and (3))": "1 OR NOT 2 AND 3",
+	} {
+		combination, err := parseAssetCombination(expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", expr, err)
+		}
+		if rendered := renderAssetCombination(combination, ""); rendered != expected {
+			t.Errorf("parse %q: expected %q, got %q", expr, expected, rendered)
+		}
+	}
+
+	for _, expr := range []string{"", "1 AND", "(1 OR 2", "1 2", "asset AND 2", "AND 1", "1)"} {
+		if _, err := parseAssetCombination(expr); err == nil {
+			t.Errorf("parse %q: expected an error", expr)
+		} else if !strings.Contains(err.Error(), "asset") {
+			t.Errorf("parse %q: expected error to mention the asset combination, got %v", expr, err)
+		}
+	}
+}
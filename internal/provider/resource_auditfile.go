@@ -19,7 +19,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 // ResourceAuditFile Initialize the Accept Risk Resource
@@ -32,7 +32,9 @@ func ResourceAuditFile() *schema.Resource {
 		DeleteContext: resourceAuditFileDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "audit file", (*tenablesc.Client).GetAllAuditFiles, func(a *tenablesc.AuditFile) (string, string) {
+				return string(a.ID), a.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,7 +64,7 @@ func ResourceAuditFile() *schema.Resource {
 }
 
 func resourceAuditFileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	var err error
@@ -72,7 +74,7 @@ func resourceAuditFileCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 	filename, err := uploadNewAuditFile(sc, name, content)
 	if err != nil {
-		Logf(logError, "Upload file response: %+v ", err)
+		logError(ctx, "failed to upload audit file", map[string]interface{}{"error": err.Error()})
 		return diag.FromErr(err)
 	}
 
@@ -83,24 +85,20 @@ func resourceAuditFileCreate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
-	Logf(logDebug, "response: %+v", response)
-
 	d.SetId(string(response.ID))
 
 	return resourceAuditFileRead(ctx, d, m)
 }
 
 func resourceAuditFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	auditFile, err := sc.GetAuditFile(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", auditFile)
-
 	d.SetId(string(auditFile.ID))
 	d.Set("name", auditFile.Name)
 	d.Set("description", auditFile.Description)
@@ -118,7 +116,7 @@ func uploadNewAuditFile(sc *tenablesc.Client, name, content string) (string, err
 }
 
 func resourceAuditFileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	oldFilename := d.Get("sc_filename").(string)
@@ -132,7 +130,7 @@ func resourceAuditFileUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		d.Set("sc_filename", filename)
 	}
 
-	auditFile, err := sc.UpdateAuditFile(buildAuditFileInput(d))
+	_, err := sc.UpdateAuditFile(buildAuditFileInput(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -152,18 +150,17 @@ func resourceAuditFileUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			}
 		}
 	}
-	Logf(logDebug, "response: %+v", auditFile)
 
 	return resourceAuditFileRead(ctx, d, m)
 }
 
 func resourceAuditFileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteAuditFile(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
@@ -0,0 +1,45 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestResourceAuditFileLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":    "test.audit",
+		"content": "<check_type:\"Unix\">\n</check_type>",
+	}
+
+	state := h.apply("tenablesc_auditfile", nil, config)
+	firstFilename := state.Attributes["sc_filename"]
+	if content, ok := h.server.File(firstFilename); !ok || content != config["content"] {
+		t.Fatalf("expected uploaded content for %s, got %q", firstFilename, content)
+	}
+
+	config["content"] = "<check_type:\"Windows\">\n</check_type>"
+	state = h.apply("tenablesc_auditfile", state, config)
+	if state.Attributes["sc_filename"] == firstFilename {
+		t.Fatal("expected new file to be uploaded on content change")
+	}
+	if content, _ := h.server.File(state.Attributes["sc_filename"]); content != config["content"] {
+		t.Fatalf("expected updated content to be uploaded, got %q", content)
+	}
+
+	h.destroy("tenablesc_auditfile", state)
+}
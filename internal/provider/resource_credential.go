@@ -0,0 +1,465 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// credentialTypeBlocks are the typed sub-blocks of the credential resource, named after the SC credential type.
+var credentialTypeBlocks = []string{"ssh", "windows", "database", "snmp"}
+
+// credentialSecretFields lists the write-only fields of each credential type block.
+var credentialSecretFields = map[string][]string{
+	"ssh":      {"password", "private_key", "public_key", "passphrase", "escalation_password"},
+	"windows":  {"password"},
+	"database": {"password"},
+	"snmp":     {"community_string"},
+}
+
+// ResourceCredential Initialize the Credential Resource
+func ResourceCredential() *schema.Resource {
+	return &schema.Resource{
+		Description:   descriptionResourceCredential,
+		CreateContext: resourceCredentialCreate,
+		ReadContext:   resourceCredentialRead,
+		UpdateContext: resourceCredentialUpdate,
+		DeleteContext: resourceCredentialDelete,
+		CustomizeDiff: resourceCredentialCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: descriptionCredentialName,
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: descriptionCredentialDescription,
+				Optional:    true,
+				Default:     descriptionDefaultDescriptionValue,
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: descriptionCredentialTags,
+				Optional:    true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: descriptionCredentialType,
+				Computed:    true,
+			},
+			"ssh": {
+				Type:         schema.TypeList,
+				Description:  descriptionCredentialSSH,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: credentialTypeBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth_type": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialSSHAuthType,
+							Required:    true,
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialUsername,
+							Required:    true,
+						},
+						"password":    credentialSecretSchema(descriptionCredentialPassword),
+						"private_key": credentialSecretSchema(descriptionCredentialPrivateKey),
+						"public_key":  credentialSecretSchema(descriptionCredentialPublicKey),
+						"passphrase":  credentialSecretSchema(descriptionCredentialPassphrase),
+						"privilege_escalation": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialPrivilegeEscalation,
+							Optional:    true,
+							Default:     "none",
+						},
+						"escalation_username": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialEscalationUsername,
+							Optional:    true,
+						},
+						"escalation_password": credentialSecretSchema(descriptionCredentialEscalationPassword),
+						"escalation_path": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialEscalationPath,
+							Optional:    true,
+						},
+						"escalation_su_user": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialEscalationSuUser,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"windows": {
+				Type:         schema.TypeList,
+				Description:  descriptionCredentialWindows,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: credentialTypeBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth_type": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialWindowsAuthType,
+							Optional:    true,
+							Default:     "password",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialUsername,
+							Required:    true,
+						},
+						"password": credentialSecretSchema(descriptionCredentialPassword),
+						"domain": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialDomain,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"database": {
+				Type:         schema.TypeList,
+				Description:  descriptionCredentialDatabase,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: credentialTypeBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth_type": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialDatabaseAuthType,
+							Optional:    true,
+							Default:     "password",
+						},
+						"db_type": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialDBType,
+							Required:    true,
+						},
+						"login": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialUsername,
+							Required:    true,
+						},
+						"password": credentialSecretSchema(descriptionCredentialPassword),
+						"port": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialDBPort,
+							Required:    true,
+						},
+						"sid": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialDBSID,
+							Optional:    true,
+						},
+						"oracle_auth_type": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialOracleAuthType,
+							Optional:    true,
+						},
+						"sql_server_auth_type": {
+							Type:        schema.TypeString,
+							Description: descriptionCredentialSQLServerAuthType,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"snmp": {
+				Type:         schema.TypeList,
+				Description:  descriptionCredentialSNMP,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: credentialTypeBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"community_string": credentialSecretSchema(descriptionCredentialCommunityString),
+					},
+				},
+			},
+		},
+	}
+}
+
+// credentialSecretSchema describes a write-only secret. SC never returns secrets, so state
+// only ever holds a hash of the configured value; that is enough to notice when it changes.
+func credentialSecretSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: description,
+		Optional:    true,
+		Sensitive:   true,
+		StateFunc:   hashSecret,
+	}
+}
+
+func hashSecret(v interface{}) string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// configuredCredentialType returns the typed block set in the configuration, which is also the SC credential type.
+func configuredCredentialType(getOk func(string) (interface{}, bool)) string {
+	for _, block := range credentialTypeBlocks {
+		if _, ok := getOk(block); ok {
+			return block
+		}
+	}
+	return ""
+}
+
+// hashCredentialSecrets replaces the plaintext secrets read from the configuration with their hashes,
+// as nested StateFuncs are not applied to values written back to state.
+func hashCredentialSecrets(d *schema.ResourceData, block string, create bool) {
+	l := d.Get(block).([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return
+	}
+
+	fields := l[0].(map[string]interface{})
+	for _, k := range credentialSecretFields[block] {
+		if create || d.HasChange(fmt.Sprintf("%s.0.%s", block, k)) {
+			fields[k] = hashSecret(fields[k])
+		}
+	}
+
+	d.Set(block, []interface{}{fields})
+}
+
+// resourceCredentialCustomizeDiff forces replacement when switching between credential types,
+// as SC does not allow changing the type of an existing credential.
+func resourceCredentialCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	credentialType := configuredCredentialType(d.GetOk)
+	if credentialType == "" || d.Get("type").(string) == credentialType {
+		return nil
+	}
+
+	if err := d.SetNew("type", credentialType); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return d.ForceNew("type")
+}
+
+func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	input, err := buildCredentialInput(d, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := sc.CreateCredential(input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(string(response.ID))
+	hashCredentialSecrets(d, input.Type, true)
+
+	return resourceCredentialRead(ctx, d, m)
+}
+
+func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	credential, err := sc.GetCredentialDetails(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	// Don't log the type fields; even though SC shouldn't return secrets, there's no reason to find out.
+
+	d.SetId(string(credential.ID))
+	d.Set("name", credential.Name)
+	d.Set("description", credential.Description)
+	d.Set("tags", credential.Tags)
+	d.Set("type", credential.Type)
+
+	block := credential.Type
+	if _, ok := credentialSecretFields[block]; !ok {
+		return diag.Errorf("credential %s has type '%s', which is not supported", d.Id(), credential.Type)
+	}
+
+	// SC never returns secrets, so carry over the hashes already in state.
+	fields := map[string]interface{}{}
+	for _, k := range credentialSecretFields[block] {
+		fields[k] = d.Get(fmt.Sprintf("%s.0.%s", block, k))
+	}
+
+	tf := credential.CredentialTypeFields
+	switch block {
+	case "ssh":
+		fields["auth_type"] = tf.AuthType
+		fields["username"] = tf.Username
+		fields["privilege_escalation"] = tf.PrivilegeEscalation
+		fields["escalation_username"] = tf.EscalationUsername
+		fields["escalation_path"] = tf.EscalationPath
+		fields["escalation_su_user"] = tf.EscalationSuUser
+	case "windows":
+		fields["auth_type"] = tf.AuthType
+		fields["username"] = tf.Username
+		fields["domain"] = tf.Domain
+	case "database":
+		fields["auth_type"] = tf.AuthType
+		fields["db_type"] = tf.DBType
+		fields["login"] = tf.Login
+		fields["port"] = tf.Port
+		fields["sid"] = tf.SID
+		fields["oracle_auth_type"] = tf.OracleAuthType
+		fields["sql_server_auth_type"] = tf.SQLServerAuthType
+	}
+
+	for _, b := range credentialTypeBlocks {
+		if b == block {
+			d.Set(b, []interface{}{fields})
+		} else {
+			d.Set(b, nil)
+		}
+	}
+
+	return nil
+}
+
+func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	input, err := buildCredentialInput(d, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = sc.UpdateCredential(input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hashCredentialSecrets(d, input.Type, false)
+
+	return resourceCredentialRead(ctx, d, m)
+}
+
+func resourceCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	err := sc.DeleteCredential(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	return nil
+}
+
+// buildCredentialInput renders the configured credential. Secrets are only sent when creating or
+// when they have changed, as outside of those cases only their hash is known. Secrets removed from the
+// configuration are sent empty, so SC doesn't keep using them.
+func buildCredentialInput(d *schema.ResourceData, create bool) (*tenablesc.CredentialDetails, error) {
+	block := configuredCredentialType(d.GetOk)
+	if block == "" {
+		return nil, fmt.Errorf("one of %v must be configured", credentialTypeBlocks)
+	}
+
+	get := func(k string) string {
+		return d.Get(fmt.Sprintf("%s.0.%s", block, k)).(string)
+	}
+	var cleared []string
+	secret := func(k, field string) string {
+		key := fmt.Sprintf("%s.0.%s", block, k)
+		if !create && !d.HasChange(key) {
+			return ""
+		}
+		value := d.Get(key).(string)
+		if value == "" && !create {
+			cleared = append(cleared, field)
+		}
+		return value
+	}
+
+	input := &tenablesc.CredentialDetails{
+		Credential: tenablesc.Credential{
+			BaseInfo: tenablesc.BaseInfo{
+				ID:          tenablesc.ProbablyString(d.Id()),
+				Name:        d.Get("name").(string),
+				Description: d.Get("description").(string),
+			},
+			Type: block,
+			Tags: d.Get("tags").(string),
+		},
+	}
+
+	tf := &input.CredentialTypeFields
+	switch block {
+	case "ssh":
+		tf.AuthType = get("auth_type")
+		tf.Username = get("username")
+		tf.Password = secret("password", "password")
+		tf.PrivateKey = secret("private_key", "privateKey")
+		tf.PublicKey = secret("public_key", "publicKey")
+		tf.Passphrase = secret("passphrase", "passphrase")
+		tf.PrivilegeEscalation = get("privilege_escalation")
+		tf.EscalationUsername = get("escalation_username")
+		tf.EscalationPassword = secret("escalation_password", "escalationPassword")
+		tf.EscalationPath = get("escalation_path")
+		tf.EscalationSuUser = get("escalation_su_user")
+	case "windows":
+		tf.AuthType = get("auth_type")
+		tf.Username = get("username")
+		tf.Password = secret("password", "password")
+		tf.Domain = get("domain")
+	case "database":
+		tf.AuthType = get("auth_type")
+		tf.DBType = get("db_type")
+		tf.Login = get("login")
+		tf.Password = secret("password", "password")
+		tf.Port = get("port")
+		tf.SID = get("sid")
+		tf.OracleAuthType = get("oracle_auth_type")
+		tf.SQLServerAuthType = get("sql_server_auth_type")
+	case "snmp":
+		tf.AuthType = "snmp"
+		tf.CommunityString = secret("community_string", "communityString")
+	}
+	input.ClearedSecrets = cleared
+
+	return input, nil
+}
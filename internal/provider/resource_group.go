@@ -0,0 +1,161 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// ResourceGroup Initialize the Group Resource
+func ResourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   descriptionResourceGroup,
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: descriptionGroupName,
+				Required:    true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: descriptionGroupDescription,
+				Optional:    true,
+				Default:     descriptionDefaultDescriptionValue,
+			},
+			"defining_asset_ids": {
+				Type:        schema.TypeSet,
+				Description: descriptionGroupDefiningAssetIDs,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+			"repository_ids": {
+				Type:        schema.TypeSet,
+				Description: descriptionGroupRepositoryIDs,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+			"share_create_user": {
+				Type:        schema.TypeBool,
+				Description: descriptionGroupShareCreateUser,
+				Optional:    true,
+				Default:     false,
+			},
+			"share_assets": {
+				Type:        schema.TypeBool,
+				Description: descriptionGroupShareAssets,
+				Optional:    true,
+				Default:     false,
+			},
+			"share_policies": {
+				Type:        schema.TypeBool,
+				Description: descriptionGroupSharePolicies,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	group, err := sc.CreateGroup(buildGroupInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(string(group.ID))
+
+	return resourceGroupRead(ctx, d, m)
+}
+
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	group, err := sc.GetGroup(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	d.SetId(string(group.ID))
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	d.Set("defining_asset_ids", unbundleIDs(group.DefiningAssets))
+	d.Set("repository_ids", unbundleIDs(group.Repositories))
+	d.Set("share_create_user", group.CreateUser.AsBool())
+	d.Set("share_assets", group.Assets.AsBool())
+	d.Set("share_policies", group.Policies.AsBool())
+
+	return nil
+}
+
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	_, err := sc.UpdateGroup(buildGroupInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGroupRead(ctx, d, m)
+}
+
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	err := sc.DeleteGroup(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	return nil
+}
+
+func buildGroupInput(d *schema.ResourceData) *tenablesc.Group {
+	group := &tenablesc.Group{
+		BaseInfo: tenablesc.BaseInfo{
+			ID:          tenablesc.ProbablyString(d.Id()),
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		},
+		DefiningAssets: []tenablesc.BaseInfo{},
+		Repositories:   []tenablesc.BaseInfo{},
+		CreateUser:     tenablesc.ToFakeBool(d.Get("share_create_user").(bool)),
+		Assets:         tenablesc.ToFakeBool(d.Get("share_assets").(bool)),
+		Policies:       tenablesc.ToFakeBool(d.Get("share_policies").(bool)),
+	}
+
+	group.DefiningAssets = append(group.DefiningAssets, bundleIDs(d.Get("defining_asset_ids").(*schema.Set).List())...)
+	group.Repositories = append(group.Repositories, bundleIDs(d.Get("repository_ids").(*schema.Set).List())...)
+
+	return group
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceGroupLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":               "analysts",
+		"defining_asset_ids": []interface{}{"10", "11"},
+		"repository_ids":     []interface{}{"1"},
+		"share_assets":       true,
+	}
+
+	state := h.apply("tenablesc_group", nil, config)
+	requireAttribute(t, state, "description", descriptionDefaultDescriptionValue)
+	requireAttribute(t, state, "defining_asset_ids.#", "2")
+	requireAttribute(t, state, "repository_ids.#", "1")
+	requireAttribute(t, state, "share_assets", "true")
+	requireAttribute(t, state, "share_policies", "false")
+	h.requireEmptyPlan("tenablesc_group", state, config)
+
+	stored := h.server.Get(fakesc.EndpointGroup, state.ID)
+	if stored["assets"] != "true" || stored["createUser"] != "false" {
+		t.Fatalf("expected sharing flags to be sent to SC, got %v", stored)
+	}
+
+	// Emptying the lists must clear them in SC, not leave them untouched.
+	delete(config, "defining_asset_ids")
+	config["repository_ids"] = []interface{}{}
+	config["share_create_user"] = true
+	state = h.apply("tenablesc_group", state, config)
+	requireAttribute(t, state, "defining_asset_ids.#", "0")
+	requireAttribute(t, state, "repository_ids.#", "0")
+	requireAttribute(t, state, "share_create_user", "true")
+	if stored := h.server.Get(fakesc.EndpointGroup, state.ID); len(stored["definingAssets"].([]interface{})) != 0 {
+		t.Fatalf("expected defining assets to be cleared, got %v", stored["definingAssets"])
+	}
+
+	imported := h.importState("tenablesc_group", state.ID)
+	requireAttribute(t, imported, "name", "analysts")
+	requireAttribute(t, imported, "share_create_user", "true")
+
+	h.destroy("tenablesc_group", state)
+	if h.server.Count(fakesc.EndpointGroup) != 0 {
+		t.Fatal("expected group to be deleted")
+	}
+}
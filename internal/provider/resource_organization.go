@@ -23,7 +23,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func ResourceOrganization() *schema.Resource {
@@ -35,7 +35,9 @@ func ResourceOrganization() *schema.Resource {
 		DeleteContext: resourceOrganizationDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "organization", (*tenablesc.Client).GetAllOrganizations, func(o *tenablesc.Organization) (string, string) {
+				return string(o.ID), o.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -74,7 +76,7 @@ func ResourceOrganization() *schema.Resource {
 }
 
 func resourceOrganizationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	orgInputs, diags := buildOrgInputs(d)
@@ -92,17 +94,15 @@ func resourceOrganizationCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	organization, err := sc.GetOrganization(d.Id())
 
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", organization)
-
 	d.SetId(string(organization.ID))
 
 	d.Set("name", organization.Name)
@@ -118,7 +118,7 @@ func resourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceOrganizationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	orgInputs, diags := buildOrgInputs(d)
@@ -135,12 +135,12 @@ func resourceOrganizationUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceOrganizationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteOrganization(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
@@ -20,7 +20,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func ResourceOrganizationScanZoneAssociation() *schema.Resource {
@@ -32,7 +32,9 @@ func ResourceOrganizationScanZoneAssociation() *schema.Resource {
 		DeleteContext: resourceOrganizationScanZoneAssociationDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("organization", "organization", (*tenablesc.Client).GetAllOrganizations, func(o *tenablesc.Organization) (string, string) {
+				return string(o.ID), o.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -52,7 +54,7 @@ func ResourceOrganizationScanZoneAssociation() *schema.Resource {
 }
 
 func resourceOrganizationScanZoneAssociationCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	d.SetId(d.Get("organization_id").(string))
@@ -64,30 +66,22 @@ func resourceOrganizationScanZoneAssociationCreateOrUpdate(ctx context.Context,
 
 	_, err = sc.UpdateOrganization(orgAssociationInput)
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return resourceOrganizationScanZoneAssociationRead(ctx, d, m)
 }
 
 func resourceOrganizationScanZoneAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
-	id := d.Get("organization_id").(string)
-
-	org, err := sc.GetOrganization(id)
+	org, err := sc.GetOrganization(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", org)
-
-	idInt, err := strconv.ParseInt(string(org.ID), 10, 32)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	d.Set("organization_id", int(idInt))
+	d.Set("organization_id", string(org.ID))
 	d.SetId(string(org.ID))
 
 	var zoneList []int
@@ -104,7 +98,7 @@ func resourceOrganizationScanZoneAssociationRead(ctx context.Context, d *schema.
 }
 
 func resourceOrganizationScanZoneAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	id := d.Id()
@@ -116,7 +110,7 @@ func resourceOrganizationScanZoneAssociationDelete(ctx context.Context, d *schem
 
 	_, err := sc.UpdateOrganization(orgAssociation)
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 	return nil
 }
@@ -20,7 +20,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 // ResourceRecastRisk Initialize the Recast Resource
@@ -93,7 +93,7 @@ func ResourceRecastRisk() *schema.Resource {
 }
 
 func resourceRecastRiskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
@@ -107,27 +107,23 @@ func resourceRecastRiskCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(fmt.Errorf("failed to create recast risk rule: %w", err))
 	}
 
-	Logf(logDebug, "response: %+v", recastRiskResponse)
-
 	d.SetId(recastRiskResponse.ID)
 
 	return resourceRecastRiskRead(ctx, d, m)
 }
 
 func resourceRecastRiskRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
 	recastRiskResponse, err := sc.GetRecastRiskRule(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	d.Set("plugin_id", recastRiskResponse.Plugin.ID)
 
-	Logf(logDebug, "response: %+v", recastRiskResponse)
-
 	hostType := recastRiskResponse.HostType
 	d.Set("host_type", hostType)
 	d.Set("host_value", recastRiskResponse.HostValue)
@@ -143,29 +139,31 @@ func resourceRecastRiskRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceRecastRiskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
-
-	deleteError := resourceRecastRiskDelete(ctx, d, m)
-	if deleteError != nil {
-		return deleteError
-	}
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
 
-	createError := resourceRecastRiskCreate(ctx, d, m)
-	if createError != nil {
-		return createError
+	recastRiskInput, err := buildRecastRiskInput(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to build recast risk rule input: %w", err))
 	}
 
-	return resourceRecastRiskRead(ctx, d, m)
+	return replaceRiskRule(ctx, d, m, "recast risk", func() (string, error) {
+		created, err := sc.CreateRecastRiskRule(recastRiskInput)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	}, sc.DeleteRecastRiskRule, resourceRecastRiskRead)
 }
 
 func resourceRecastRiskDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteRecastRiskRule(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 	return nil
 }
@@ -186,7 +184,6 @@ func buildRecastRiskInput(d *schema.ResourceData) (*tenablesc.RecastRiskRule, er
 
 	rrInput := &tenablesc.RecastRiskRule{
 		RecastRiskRuleBaseFields: tenablesc.RecastRiskRuleBaseFields{
-			ID:       d.Id(),
 			Plugin:   tenablesc.BaseInfo{ID: tenablesc.ProbablyString(pluginID)},
 			Port:     port,
 			Protocol: protocol,
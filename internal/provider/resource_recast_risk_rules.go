@@ -0,0 +1,129 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// ResourceRecastRiskRules Initialize the Recast Risk Rules Resource
+func ResourceRecastRiskRules() *schema.Resource {
+	return riskRuleSet{
+		kind: "recast risk",
+		rule: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"plugin_id": {
+					Type:        schema.TypeString,
+					Description: descriptionPluginID,
+					Required:    true,
+				},
+				"new_severity": {
+					Type:        schema.TypeString,
+					Description: descriptionRecastNewSeverity,
+					Optional:    true,
+					Default:     "0",
+				},
+				"host_type": {
+					Type:        schema.TypeString,
+					Description: descriptionRiskRuleHostType,
+					Optional:    true,
+					Default:     "all",
+				},
+				"host_value": {
+					Type:        schema.TypeString,
+					Description: descriptionRiskRuleHostValue,
+					Optional:    true,
+					Default:     "",
+				},
+				"port": {
+					Type:        schema.TypeString,
+					Description: descriptionPort,
+					Optional:    true,
+					Default:     "any",
+				},
+				"protocol": {
+					Type:             schema.TypeString,
+					Description:      descriptionProtocol,
+					Optional:         true,
+					Default:          "any",
+					ValidateDiagFunc: validateRecastAcceptRiskProtocol,
+				},
+				"comments": {
+					Type:        schema.TypeString,
+					Description: descriptionComments,
+					Optional:    true,
+					Default:     descriptionDefaultDescriptionValue,
+				},
+			},
+		},
+		list:   listRecastRiskRules,
+		create: createRecastRiskRulesMember,
+		remove: func(sc *tenablesc.Client, id string) error {
+			return sc.DeleteRecastRiskRule(id)
+		},
+	}.resource(descriptionResourceRecastRiskRules)
+}
+
+func listRecastRiskRules(sc *tenablesc.Client) ([]riskRuleSetMember, error) {
+	recastRisks, err := sc.GetAllRecastRiskRules()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]riskRuleSetMember, 0, len(recastRisks))
+	for _, recastRisk := range recastRisks {
+		members = append(members, riskRuleSetMember{
+			id:           recastRisk.ID,
+			repositoryID: string(recastRisk.Repository.ID),
+			rule: map[string]interface{}{
+				"plugin_id":    string(recastRisk.Plugin.ID),
+				"new_severity": recastRisk.NewSeverity,
+				"host_type":    recastRisk.HostType,
+				"host_value":   riskRuleHostValue(recastRisk.HostValue),
+				"port":         recastRisk.Port,
+				"protocol":     riskRuleProtocolName(recastRisk.Protocol),
+				"comments":     recastRisk.Comments,
+			},
+		})
+	}
+	return members, nil
+}
+
+func createRecastRiskRulesMember(sc *tenablesc.Client, repositoryID string, rule map[string]interface{}) (string, error) {
+	protocol, err := getRecastAcceptRiskProtocolID(rule["protocol"].(string))
+	if err != nil {
+		return "", fmt.Errorf("failed to get protocol id: %w", err)
+	}
+
+	created, err := sc.CreateRecastRiskRule(&tenablesc.RecastRiskRule{
+		RecastRiskRuleBaseFields: tenablesc.RecastRiskRuleBaseFields{
+			Plugin:   tenablesc.BaseInfo{ID: tenablesc.ProbablyString(rule["plugin_id"].(string))},
+			Port:     rule["port"].(string),
+			Protocol: protocol,
+			Comments: rule["comments"].(string),
+			HostType: rule["host_type"].(string),
+		},
+		Repository:  tenablesc.BaseInfo{ID: tenablesc.ProbablyString(repositoryID)},
+		NewSeverity: rule["new_severity"].(string),
+		HostValue:   rule["host_value"].(string),
+	})
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
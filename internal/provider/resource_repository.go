@@ -16,11 +16,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func ResourceRepository() *schema.Resource {
@@ -32,9 +35,13 @@ func ResourceRepository() *schema.Resource {
 		DeleteContext: resourceRepositoryDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "repository", (*tenablesc.Client).GetAllRepositories, func(r *tenablesc.Repository) (string, string) {
+				return string(r.ID), r.Name
+			}),
 		},
 
+		CustomizeDiff: validateRepoTypeFields,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -47,10 +54,42 @@ func ResourceRepository() *schema.Resource {
 				Optional:    true,
 				Default:     descriptionDefaultDescriptionValue,
 			},
+			"data_format": {
+				Type:             schema.TypeString,
+				Description:      descriptionRepositoryDataFormat,
+				Optional:         true,
+				Default:          tenablesc.RepoDataFormatIPv4,
+				ForceNew:         true,
+				ValidateDiagFunc: validateRepoDataFormat,
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Description:      descriptionRepositoryType,
+				Optional:         true,
+				Default:          tenablesc.RepoTypeLocal,
+				ForceNew:         true,
+				ValidateDiagFunc: validateRepoType,
+			},
 			"ip_range": {
 				Type:        schema.TypeString,
 				Description: descriptionRepositoryIPRange,
-				Required:    true,
+				Optional:    true,
+				// Remote repositories take their range from the remote SC.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("type").(string) == tenablesc.RepoTypeRemote && new == ""
+				},
+			},
+			"remote_host": {
+				Type:        schema.TypeString,
+				Description: descriptionRepositoryRemoteHost,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"remote_repository_id": {
+				Type:        schema.TypeString,
+				Description: descriptionRepositoryRemoteRepositoryID,
+				Optional:    true,
+				ForceNew:    true,
 			},
 			"trending_days": {
 				Type:        schema.TypeInt,
@@ -96,7 +135,7 @@ func ResourceRepository() *schema.Resource {
 }
 
 func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
@@ -111,23 +150,34 @@ func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
 	repository, err := sc.GetRepository(d.Id())
 	if err != nil {
-		d.SetId("")
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", repository)
+	if !repository.IsModeled() {
+		return diag.Errorf("repository %s has unsupported type %s and data format %s", repository.ID, repository.Type, repository.DataFormat)
+	}
 
 	d.SetId(string(repository.ID))
 	d.Set("name", repository.Name)
 	d.Set("description", repository.Description)
+	d.Set("data_format", repository.DataFormat)
+	d.Set("type", repository.Type)
 	d.Set("ip_range", repository.IPRange)
-	d.Set("trending_days", repository.TrendingDays)
+	d.Set("remote_host", repository.RemoteIP)
+	d.Set("remote_repository_id", repository.RemoteID)
+	if repository.TrendingDays != "" {
+		trendingDays, err := strconv.Atoi(repository.TrendingDays)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("trending_days", trendingDays)
+	}
 	d.Set("trend_with_raw", repository.TrendWithRaw.AsBool())
 
 	vulnLifetimes := make(map[string]interface{})
@@ -172,20 +222,20 @@ func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteRepository(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
 }
 
 func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	_, err := sc.UpdateRepository(buildRepoInputs(d))
@@ -208,11 +258,15 @@ func buildRepoInputs(d *schema.ResourceData) *tenablesc.Repository {
 				Name:        name,
 				Description: description,
 			},
-			DataFormat: "IPv4",  // will need to be updated when we add agent repos.
-			Type:       "Local", // Other options: remote, offline.
+			DataFormat: d.Get("data_format").(string),
+			Type:       d.Get("type").(string),
 		},
 		RepoFieldsCommon: tenablesc.RepoFieldsCommon{},
 		RepoIPFields:     tenablesc.RepoIPFields{},
+		RepoRemoteFields: tenablesc.RepoRemoteFields{
+			RemoteIP: d.Get("remote_host").(string),
+			RemoteID: d.Get("remote_repository_id").(string),
+		},
 	}
 
 	repo.IPRange = d.Get("ip_range").(string)
@@ -241,3 +295,42 @@ func buildRepoInputs(d *schema.ResourceData) *tenablesc.Repository {
 
 	return repo
 }
+
+func validateRepoDataFormat(i interface{}, path cty.Path) diag.Diagnostics {
+	switch i.(string) {
+	case tenablesc.RepoDataFormatIPv4, tenablesc.RepoDataFormatIPv6, tenablesc.RepoDataFormatAgent, tenablesc.RepoDataFormatUniversal:
+		return nil
+	}
+	return diag.Errorf("%s is not a supported repository data format. Valid formats are IPv4, IPv6, agent and universal", i)
+}
+
+func validateRepoType(i interface{}, path cty.Path) diag.Diagnostics {
+	switch i.(string) {
+	case tenablesc.RepoTypeLocal, tenablesc.RepoTypeRemote, tenablesc.RepoTypeOffline:
+		return nil
+	}
+	return diag.Errorf("%s is not a supported repository type. Valid types are Local, Remote and Offline", i)
+}
+
+// validateRepoTypeFields ensures the fields required by the repository's type and data format are given.
+func validateRepoTypeFields(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	dataFormat := d.Get("data_format").(string)
+	repoType := d.Get("type").(string)
+
+	_, hasIPRange := d.GetOk("ip_range")
+	_, hasRemoteHost := d.GetOk("remote_host")
+	_, hasRemoteID := d.GetOk("remote_repository_id")
+
+	switch {
+	case repoType == tenablesc.RepoTypeRemote && (!hasRemoteHost || !hasRemoteID):
+		return fmt.Errorf("Remote repositories require remote_host and remote_repository_id")
+	case repoType != tenablesc.RepoTypeRemote && (hasRemoteHost || hasRemoteID):
+		return fmt.Errorf("remote_host and remote_repository_id are only valid for Remote repositories")
+	case repoType != tenablesc.RepoTypeRemote && strings.HasPrefix(dataFormat, "IP") && !hasIPRange && d.NewValueKnown("ip_range"):
+		return fmt.Errorf("%s %s repositories require ip_range", repoType, dataFormat)
+	case !strings.HasPrefix(dataFormat, "IP") && hasIPRange:
+		return fmt.Errorf("ip_range is only valid for IPv4 and IPv6 repositories")
+	}
+
+	return nil
+}
@@ -16,14 +16,13 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 func ResourceRepositoryOrganizationAssociation() *schema.Resource {
@@ -35,7 +34,9 @@ func ResourceRepositoryOrganizationAssociation() *schema.Resource {
 		DeleteContext: resourceRepositoryOrganizationAssociationDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("repository", "repository", (*tenablesc.Client).GetAllRepositories, func(r *tenablesc.Repository) (string, string) {
+				return string(r.ID), r.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,7 +69,7 @@ func ResourceRepositoryOrganizationAssociation() *schema.Resource {
 }
 
 func resourceRepositoryOrganizationAssociationCreateOrUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
@@ -88,17 +89,16 @@ func resourceRepositoryOrganizationAssociationCreateOrUpdate(ctx context.Context
 }
 
 func resourceRepositoryOrganizationAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	repository, err := sc.GetRepository(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", repository)
-
 	d.SetId(string(repository.ID))
+	d.Set("repository_id", string(repository.ID))
 
 	organizations := make([]map[string]interface{}, 0)
 	for _, ro := range repository.Organizations {
@@ -132,7 +132,7 @@ func resourceRepositoryOrganizationAssociationDelete(ctx context.Context, d *sch
 
 	_, err := sc.UpdateRepository(repositoryAssociation)
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 	return nil
 }
@@ -173,13 +173,6 @@ func buildRepositoryOrganizationAssociationInputs(d *schema.ResourceData) (*tena
 
 	repositoryAssociation.Organizations = repoOrgs
 
-	repositoryAssociationBytes, err := json.Marshal(repositoryAssociation)
-	if err != nil {
-		return nil, err
-
-	}
-	Logf(logDebug, fmt.Sprintf("built Repository Association: %s", repositoryAssociationBytes))
-
 	return repositoryAssociation, nil
 }
 
@@ -0,0 +1,151 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceRepositoryLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":          "test repository",
+		"ip_range":      "10.0.0.0/8",
+		"trending_days": 60,
+	}
+
+	state := h.apply("tenablesc_repository", nil, config)
+	requireAttribute(t, state, "ip_range", "10.0.0.0/8")
+	requireAttribute(t, state, "trending_days", "60")
+	requireAttribute(t, state, "trend_with_raw", "false")
+	h.requireEmptyPlan("tenablesc_repository", state, config)
+
+	config["trend_with_raw"] = true
+	config["ip_range"] = "10.0.0.0/16"
+	state = h.apply("tenablesc_repository", state, config)
+	requireAttribute(t, state, "ip_range", "10.0.0.0/16")
+	requireAttribute(t, state, "trend_with_raw", "true")
+
+	imported := h.importState("tenablesc_repository", state.ID)
+	requireAttribute(t, imported, "ip_range", "10.0.0.0/16")
+
+	h.destroy("tenablesc_repository", state)
+}
+
+func TestResourceRepositoryAgentAndRemote(t *testing.T) {
+	h := newTestHarness(t)
+
+	agent := map[string]interface{}{
+		"name":        "agents",
+		"data_format": "agent",
+	}
+	state := h.apply("tenablesc_repository", nil, agent)
+	requireAttribute(t, state, "data_format", "agent")
+	requireAttribute(t, state, "type", "Local")
+	requireAttribute(t, state, "ip_range", "")
+	h.requireEmptyPlan("tenablesc_repository", state, agent)
+
+	remote := map[string]interface{}{
+		"name":                 "upstream",
+		"data_format":          "IPv6",
+		"type":                 "Remote",
+		"remote_host":          "sc.example.com",
+		"remote_repository_id": "4",
+	}
+	state = h.apply("tenablesc_repository", nil, remote)
+	requireAttribute(t, state, "remote_host", "sc.example.com")
+	requireAttribute(t, state, "remote_repository_id", "4")
+	h.requireEmptyPlan("tenablesc_repository", state, remote)
+
+	stored := h.server.Get(fakesc.EndpointRepository, state.ID)
+	if stored["type"] != "Remote" || stored["dataFormat"] != "IPv6" {
+		t.Fatalf("expected repository type and data format to be sent to SC, got %v", stored)
+	}
+
+	// The range of remote repositories is synced from the remote SC.
+	h.server.Update(fakesc.EndpointRepository, state.ID, fakesc.Object{"typeFields": fakesc.Object{"ipRange": "fd00::/8"}})
+	state = h.refresh("tenablesc_repository", state)
+	requireAttribute(t, state, "ip_range", "fd00::/8")
+	h.requireEmptyPlan("tenablesc_repository", state, remote)
+
+	imported := h.importState("tenablesc_repository", state.ID)
+	requireAttribute(t, imported, "type", "Remote")
+	requireAttribute(t, imported, "remote_repository_id", "4")
+
+	remote["type"] = "Offline"
+	delete(remote, "remote_host")
+	delete(remote, "remote_repository_id")
+	remote["ip_range"] = "fd00::/8"
+	if diff := h.plan("tenablesc_repository", state, remote); !diff.RequiresNew() {
+		t.Fatal("expected changing repository type to replace the repository")
+	}
+}
+
+func TestResourceRepositoryTypeFieldsValidation(t *testing.T) {
+	h := newTestHarness(t)
+
+	for name, config := range map[string]map[string]interface{}{
+		"ipv4 without ip range":    {"name": "a"},
+		"agent with ip range":      {"name": "a", "data_format": "agent", "ip_range": "10.0.0.0/8"},
+		"remote without remote id": {"name": "a", "type": "Remote", "remote_host": "sc.example.com"},
+		"local with remote host":   {"name": "a", "ip_range": "10.0.0.0/8", "remote_host": "sc.example.com"},
+		"unsupported data format":  {"name": "a", "data_format": "mobile"},
+	} {
+		r := h.resource("tenablesc_repository")
+		c := terraform.NewResourceConfigRaw(config)
+		if diags := r.Validate(c); diags.HasError() {
+			continue
+		}
+		if _, err := r.Diff(h.ctx, nil, c, h.provider.Meta()); err == nil {
+			t.Errorf("%s: expected plan to fail", name)
+		}
+	}
+}
+
+func TestResourceRepositoryOrganizationAssociationLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	repo := h.apply("tenablesc_repository", nil, map[string]interface{}{
+		"name":     "shared repository",
+		"ip_range": "10.0.0.0/8",
+	})
+	org := h.apply("tenablesc_organization", nil, map[string]interface{}{
+		"name": "tenant",
+	})
+
+	config := map[string]interface{}{
+		"repository_id": repo.ID,
+		"organization": []interface{}{
+			map[string]interface{}{
+				"organization_id":  org.Attributes["id"],
+				"group_assignment": "fullAccess",
+			},
+		},
+	}
+
+	state := h.apply("tenablesc_repository_organization_association", nil, config)
+	requireAttribute(t, state, "organization.#", "1")
+	requireAttribute(t, state, "organization.0.group_assignment", "fullAccess")
+	h.requireEmptyPlan("tenablesc_repository_organization_association", state, config)
+
+	imported := h.importState("tenablesc_repository_organization_association", repo.ID)
+	requireAttribute(t, imported, "organization.0.organization_id", org.ID)
+
+	h.destroy("tenablesc_repository_organization_association", state)
+}
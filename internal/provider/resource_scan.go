@@ -19,7 +19,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 // ResourceScan Initialize the Accept Risk Resource
@@ -32,7 +32,9 @@ func ResourceScan() *schema.Resource {
 		DeleteContext: resourceScanDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "scan", (*tenablesc.Client).GetAllScans, func(s *tenablesc.Scan) (string, string) {
+				return string(s.ID), s.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -107,7 +109,7 @@ func ResourceScan() *schema.Resource {
 }
 
 func resourceScanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
@@ -122,23 +124,21 @@ func resourceScanCreate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	scan, err := sc.GetScan(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", scan)
-
 	d.SetId(string(scan.ID))
 	d.Set("name", scan.Name)
 	d.Set("description", scan.Description)
 	d.Set("repository_id", scan.Repository.ID)
 	d.Set("policy_id", scan.Policy.ID)
-	d.Set("scan_virtual_hosts", scan.ScanningVirtualHosts)
-	d.Set("dhcp_tracking", scan.DHCPTracking)
+	d.Set("scan_virtual_hosts", scan.ScanningVirtualHosts.AsBool())
+	d.Set("dhcp_tracking", scan.DHCPTracking.AsBool())
 	d.Set("timeout_action", scan.TimeoutAction)
 	d.Set("max_scan_time", scan.MaxScanTime)
 	d.Set("ips_and_names", scan.IPList)
@@ -161,26 +161,24 @@ func resourceScanRead(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceScanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
-	scan, err := sc.UpdateScan(buildScanInputs(d))
+	_, err := sc.UpdateScan(buildScanInputs(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	Logf(logDebug, "response: %+v", scan)
-
 	return resourceScanRead(ctx, d, m)
 }
 
 func resourceScanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteScan(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
@@ -250,3 +248,12 @@ func bundleIDs(ids []interface{}) []tenablesc.BaseInfo {
 
 	return processedIDs
 }
+
+func unbundleIDs(objs []tenablesc.BaseInfo) []string {
+	var ids []string
+	for _, obj := range objs {
+		ids = append(ids, string(obj.ID))
+	}
+
+	return ids
+}
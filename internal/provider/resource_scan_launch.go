@@ -0,0 +1,221 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// scanLaunchPollInterval is how often a launched scan's result is checked for completion.
+var scanLaunchPollInterval = 30 * time.Second
+
+// scanLaunchStopTimeout bounds stopping a scan that is no longer waited for.
+const scanLaunchStopTimeout = time.Minute
+
+// scanResultFinalStatuses are the scan result statuses after which a scan will make no further progress.
+var scanResultFinalStatuses = map[string]bool{
+	"Completed": true,
+	"Partial":   true,
+	"Error":     true,
+	"Stopped":   true,
+}
+
+// ResourceScanLaunch Initialize the Scan Launch Resource
+func ResourceScanLaunch() *schema.Resource {
+	return &schema.Resource{
+		Description:   descriptionResourceScanLaunch,
+		CreateContext: resourceScanLaunchCreate,
+		ReadContext:   resourceScanLaunchRead,
+		UpdateContext: resourceScanLaunchRead,
+		DeleteContext: resourceScanLaunchDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"scan_id": {
+				Type:        schema.TypeString,
+				Description: descriptionScanID,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: descriptionScanLaunchTriggers,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fail_on_error": {
+				Type:        schema.TypeBool,
+				Description: descriptionScanLaunchFailOnError,
+				Optional:    true,
+				Default:     false,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: descriptionScanResultStatus,
+				Computed:    true,
+			},
+			"scanned_ips": {
+				Type:        schema.TypeInt,
+				Description: descriptionScanResultScannedIPs,
+				Computed:    true,
+			},
+			"total_ips": {
+				Type:        schema.TypeInt,
+				Description: descriptionScanResultTotalIPs,
+				Computed:    true,
+			},
+			"duration": {
+				Type:        schema.TypeInt,
+				Description: descriptionScanResultDuration,
+				Computed:    true,
+			},
+			"error_details": {
+				Type:        schema.TypeString,
+				Description: descriptionScanResultErrorDetails,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceScanLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	launch, err := sc.StartScan(d.Get("scan_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Track the scan result right away, so a timeout leaves it in state rather than orphaned.
+	d.SetId(string(launch.ScanResult.ID))
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	for {
+		result, err := sc.GetScanResult(d.Id())
+		if err != nil {
+			if ctx.Err() != nil {
+				return stopScanLaunch(ctx, sc, d.Id(), "unknown")
+			}
+			return diag.FromErr(err)
+		}
+
+		logDebug(ctx, "scan result status", map[string]interface{}{"status": result.Status})
+
+		if scanResultFinalStatuses[result.Status] {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return stopScanLaunch(ctx, sc, d.Id(), result.Status)
+		case <-time.After(scanLaunchPollInterval):
+		}
+	}
+
+	diags := resourceScanLaunchRead(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	if status := d.Get("status").(string); d.Get("fail_on_error").(bool) && (status == "Error" || status == "Partial") {
+		return diag.Errorf("scan result %s finished with status %s: %s", d.Id(), status, d.Get("error_details").(string))
+	}
+
+	return diags
+}
+
+// stopScanLaunch stops a scan result once the create timeout passes or the apply is cancelled, rather than
+// leave it running in SC unattended. ctx is done by then, so the stop request is made without it.
+func stopScanLaunch(ctx context.Context, sc *tenablesc.Client, id, status string) diag.Diagnostics {
+	summary := fmt.Sprintf("timed out waiting for scan result %s to finish, last status %s", id, status)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		summary = fmt.Sprintf("cancelled waiting for scan result %s to finish, last status %s", id, status)
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), scanLaunchStopTimeout)
+	defer cancel()
+
+	if err := sc.WithContext(stopCtx).StopScanResult(id); err != nil && !errors.As(err, &tenablesc.NotFoundError{}) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("The scan could not be stopped, and is still running in SC: %s", err),
+		}}
+	}
+	logInfo(ctx, "stopped scan result", map[string]interface{}{logFieldObjectID: id})
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   "The scan was stopped.",
+	}}
+}
+
+func resourceScanLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	result, err := sc.GetScanResult(d.Id())
+	if err != nil {
+		// SC purges old scan results; the launch itself still happened, so keep the last known outcome
+		// instead of launching the scan again.
+		if errors.As(err, &tenablesc.NotFoundError{}) {
+			logInfo(ctx, "scan result no longer exists, keeping last known state")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("status", result.Status)
+	d.Set("scanned_ips", probablyInt(result.ScannedIPs))
+	d.Set("total_ips", probablyInt(result.TotalIPs))
+	d.Set("duration", probablyInt(result.ScanDuration))
+	d.Set("error_details", result.ErrorDetails)
+
+	return nil
+}
+
+func resourceScanLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+
+	// The scan result is kept in SC as a record of the launch.
+	d.SetId("")
+
+	return nil
+}
+
+// probablyInt converts a numeric SC field, treating unset or invalid values as 0.
+func probablyInt(p tenablesc.ProbablyString) int {
+	i, err := strconv.Atoi(string(p))
+	if err != nil || i < 0 {
+		return 0
+	}
+	return i
+}
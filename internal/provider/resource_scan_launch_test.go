@@ -0,0 +1,124 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func fastScanLaunchPolling(t *testing.T) {
+	previous := scanLaunchPollInterval
+	scanLaunchPollInterval = time.Millisecond
+	t.Cleanup(func() { scanLaunchPollInterval = previous })
+}
+
+func TestResourceScanLaunchWaitsForCompletion(t *testing.T) {
+	fastScanLaunchPolling(t)
+	h := newTestHarness(t)
+
+	scanID := h.server.Seed(fakesc.EndpointScan, fakesc.Object{"name": "validation"})
+
+	config := map[string]interface{}{
+		"scan_id":  scanID,
+		"triggers": map[string]interface{}{"instance": "i-1"},
+	}
+
+	state := h.apply("tenablesc_scan_launch", nil, config)
+	requireAttribute(t, state, "status", "Completed")
+	requireAttribute(t, state, "scanned_ips", "4")
+	requireAttribute(t, state, "total_ips", "4")
+	requireAttribute(t, state, "duration", "42")
+	h.requireEmptyPlan("tenablesc_scan_launch", state, config)
+
+	// Changing triggers launches the scan again.
+	config["triggers"] = map[string]interface{}{"instance": "i-2"}
+	if diff := h.plan("tenablesc_scan_launch", state, config); !diff.RequiresNew() {
+		t.Fatal("expected changed triggers to launch the scan again")
+	}
+	relaunched := h.apply("tenablesc_scan_launch", nil, config)
+	if relaunched.ID == state.ID || h.server.Count(fakesc.EndpointScanResult) != 2 {
+		t.Fatalf("expected a second scan result, got %s", relaunched.ID)
+	}
+
+	// Purged scan results must not cause another launch.
+	h.server.Remove(fakesc.EndpointScanResult, state.ID)
+	refreshed := h.refresh("tenablesc_scan_launch", state)
+	requireAttribute(t, refreshed, "status", "Completed")
+
+	h.destroy("tenablesc_scan_launch", relaunched)
+}
+
+func TestResourceScanLaunchFailOnError(t *testing.T) {
+	fastScanLaunchPolling(t)
+	h := newTestHarness(t)
+
+	scanID := h.server.Seed(fakesc.EndpointScan, fakesc.Object{"name": "validation"})
+	h.server.SetScanOutcome("Partial", "2 hosts timed out")
+
+	state := h.apply("tenablesc_scan_launch", nil, map[string]interface{}{"scan_id": scanID})
+	requireAttribute(t, state, "status", "Partial")
+	requireAttribute(t, state, "scanned_ips", "2")
+	requireAttribute(t, state, "error_details", "2 hosts timed out")
+
+	diags := h.applyExpectError("tenablesc_scan_launch", nil, map[string]interface{}{
+		"scan_id":       scanID,
+		"fail_on_error": true,
+	})
+	if !strings.Contains(diags[0].Summary, "2 hosts timed out") {
+		t.Fatalf("expected error details in diagnostic, got %q", diags[0].Summary)
+	}
+}
+
+func TestResourceScanLaunchTimeout(t *testing.T) {
+	h := newTestHarness(t)
+
+	scanID := h.server.Seed(fakesc.EndpointScan, fakesc.Object{"name": "validation"})
+
+	diags := h.applyExpectError("tenablesc_scan_launch", nil, map[string]interface{}{
+		"scan_id":  scanID,
+		"timeouts": map[string]interface{}{"create": "10ms"},
+	})
+	if !strings.Contains(diags[0].Summary, "timed out") || diags[0].Detail != "The scan was stopped." {
+		t.Fatalf("expected a timeout stopping the scan, got %q: %q", diags[0].Summary, diags[0].Detail)
+	}
+	for _, r := range h.server.Requests() {
+		if strings.HasPrefix(r, "POST /scanResult/") && strings.HasSuffix(r, "/stop") {
+			return
+		}
+	}
+	t.Fatalf("expected the scan result to be stopped, got requests %v", h.server.Requests())
+}
+
+func TestResourceScanLaunchFailsOnlyOnErrorOrPartial(t *testing.T) {
+	fastScanLaunchPolling(t)
+	h := newTestHarness(t)
+
+	scanID := h.server.Seed(fakesc.EndpointScan, fakesc.Object{"name": "validation"})
+	config := map[string]interface{}{"scan_id": scanID, "fail_on_error": true}
+
+	h.server.SetScanOutcome("Stopped", "")
+	state := h.apply("tenablesc_scan_launch", nil, config)
+	requireAttribute(t, state, "status", "Stopped")
+
+	h.server.SetScanOutcome("Error", "scanner unreachable")
+	diags := h.applyExpectError("tenablesc_scan_launch", nil, config)
+	if !strings.Contains(diags[0].Summary, "scanner unreachable") {
+		t.Fatalf("expected error details in diagnostic, got %q", diags[0].Summary)
+	}
+}
@@ -20,7 +20,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 // ResourceScanPolicy Initialize the Accept Risk Resource
@@ -33,7 +33,9 @@ func ResourceScanPolicy() *schema.Resource {
 		DeleteContext: resourceScanPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "scan policy", (*tenablesc.Client).GetAllScanPolicies, func(s *tenablesc.ScanPolicy) (string, string) {
+				return string(s.ID), s.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -88,7 +90,7 @@ func ResourceScanPolicy() *schema.Resource {
 }
 
 func resourceScanPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
@@ -101,25 +103,21 @@ func resourceScanPolicyCreate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	Logf(logDebug, "response: %+v", policy)
-
 	d.SetId(string(policy.ID))
 
 	return resourceScanPolicyRead(ctx, d, m)
 }
 
 func resourceScanPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
 	policy, err := sc.GetScanPolicy(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", policy)
-
 	d.Set("name", policy.Name)
 	d.Set("description", policy.Description)
 	if policy.PolicyTemplate != nil {
@@ -155,7 +153,7 @@ func resourceScanPolicyRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceScanPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
@@ -172,13 +170,13 @@ func resourceScanPolicyUpdate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceScanPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteScanPolicy(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
@@ -201,7 +199,6 @@ func marshalPreferenceMap(m map[string]interface{}) (map[string]interface{}, err
 		}
 		marshalled[k] = string(marshalledValue)
 	}
-	Logf(logDebug, "Marshalled Preference Map: %+v", marshalled)
 	return marshalled, nil
 }
 
@@ -220,7 +217,6 @@ func unmarshalPreferencesMap(m map[string]interface{}) (map[string]interface{},
 		unmarshalled[k] = v.(string)
 	}
 
-	Logf(logDebug, "Unmarshalled Preference Map: %+v", unmarshalled)
 	return unmarshalled, nil
 }
 
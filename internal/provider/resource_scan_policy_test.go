@@ -0,0 +1,53 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceScanPolicyLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"name":               "test policy",
+		"policy_template_id": "1",
+		"preferences": map[string]interface{}{
+			"max_checks": "5",
+			"port_range": "default",
+		},
+	}
+
+	state := h.apply("tenablesc_scan_policy", nil, config)
+	requireAttribute(t, state, "preferences.max_checks", "5")
+	h.requireEmptyPlan("tenablesc_scan_policy", state, config)
+
+	config["preferences"] = map[string]interface{}{
+		"max_checks": "10",
+	}
+	state = h.apply("tenablesc_scan_policy", state, config)
+	requireAttribute(t, state, "preferences.%", "1")
+	requireAttribute(t, state, "preferences.max_checks", "10")
+
+	imported := h.importState("tenablesc_scan_policy", state.ID)
+	h.requireEmptyPlan("tenablesc_scan_policy", imported, config)
+
+	h.destroy("tenablesc_scan_policy", state)
+	if h.server.Count(fakesc.EndpointPolicy) != 0 {
+		t.Fatal("expected policy to be deleted")
+	}
+}
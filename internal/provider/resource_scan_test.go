@@ -0,0 +1,53 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceScanLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	repositoryID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"})
+	policyID := h.server.Seed(fakesc.EndpointPolicy, fakesc.Object{"name": "policy"})
+	assetID := h.server.Seed(fakesc.EndpointAsset, fakesc.Object{"name": "targets", "type": "static"})
+
+	config := map[string]interface{}{
+		"name":          "test scan",
+		"repository_id": repositoryID,
+		"policy_id":     policyID,
+		"asset_ids":     []interface{}{assetID},
+	}
+
+	state := h.apply("tenablesc_scan", nil, config)
+	requireAttribute(t, state, "repository_id", repositoryID)
+	requireAttribute(t, state, "policy_id", policyID)
+	requireAttribute(t, state, "asset_ids.0", assetID)
+	h.requireEmptyPlan("tenablesc_scan", state, config)
+
+	config["schedule_start"] = "TZID=America/New_York:20230101T000000"
+	config["schedule_repeat_rule"] = "FREQ=WEEKLY;INTERVAL=1;BYDAY=SU"
+	state = h.apply("tenablesc_scan", state, config)
+	requireAttribute(t, state, "schedule_repeat_rule", "FREQ=WEEKLY;INTERVAL=1;BYDAY=SU")
+
+	imported := h.importState("tenablesc_scan", state.ID)
+	requireAttribute(t, imported, "name", "test scan")
+	requireAttribute(t, imported, "schedule_repeat_rule", "FREQ=WEEKLY;INTERVAL=1;BYDAY=SU")
+
+	h.destroy("tenablesc_scan", state)
+}
@@ -19,7 +19,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/palantir/tenablesc-client/tenablesc"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
 )
 
 // ResourceScanZone provides CIDR to scanner and org mappings
@@ -32,7 +32,9 @@ func ResourceScanZone() *schema.Resource {
 		DeleteContext: resourceScanZoneDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("name", "scan zone", (*tenablesc.Client).GetAllScanZones, func(s *tenablesc.ScanZone) (string, string) {
+				return string(s.ID), s.Name
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,7 +60,7 @@ func ResourceScanZone() *schema.Resource {
 }
 
 func resourceScanZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	response, err := sc.CreateScanZone(buildScanZoneInput(d))
@@ -72,16 +74,14 @@ func resourceScanZoneCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceScanZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	scanZoneResponse, err := sc.GetScanZone(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
-	Logf(logDebug, "response: %+v", scanZoneResponse)
-
 	d.Set("name", scanZoneResponse.Name)
 	d.Set("description", scanZoneResponse.Description)
 	d.SetId(string(scanZoneResponse.ID))
@@ -92,7 +92,7 @@ func resourceScanZoneRead(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceScanZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	_, err := sc.UpdateScanZone(buildScanZoneInput(d))
@@ -104,12 +104,12 @@ func resourceScanZoneUpdate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceScanZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	Logf(logTrace, "start of function")
+	logTrace(ctx, "start of function")
 	sc := m.(*tenablesc.Client)
 
 	err := sc.DeleteScanZone(d.Id())
 	if err != nil {
-		return handleNotFoundError(d, err)
+		return handleNotFoundError(ctx, d, err)
 	}
 
 	return nil
@@ -0,0 +1,54 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestResourceScanZoneAndOrganizationAssociationLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	zoneConfig := map[string]interface{}{
+		"name":       "datacenter",
+		"zone_cidrs": []interface{}{"10.0.0.0/8", "192.168.0.0/16"},
+	}
+
+	zone := h.apply("tenablesc_scan_zone", nil, zoneConfig)
+	requireAttribute(t, zone, "zone_cidrs.#", "2")
+	h.requireEmptyPlan("tenablesc_scan_zone", zone, zoneConfig)
+
+	orgConfig := map[string]interface{}{
+		"name":           "tenant",
+		"restricted_ips": []interface{}{"10.0.0.0/8"},
+	}
+	org := h.apply("tenablesc_organization", nil, orgConfig)
+	h.requireEmptyPlan("tenablesc_organization", org, orgConfig)
+
+	assocConfig := map[string]interface{}{
+		"organization_id": org.ID,
+		"scan_zone_ids":   []interface{}{zone.ID},
+	}
+	assoc := h.apply("tenablesc_organization_scan_zone_association", nil, assocConfig)
+	requireAttribute(t, assoc, "scan_zone_ids.#", "1")
+	h.requireEmptyPlan("tenablesc_organization_scan_zone_association", assoc, assocConfig)
+
+	imported := h.importState("tenablesc_scan_zone", zone.ID)
+	h.requireEmptyPlan("tenablesc_scan_zone", imported, zoneConfig)
+
+	h.destroy("tenablesc_organization_scan_zone_association", assoc)
+	h.destroy("tenablesc_organization", org)
+	h.destroy("tenablesc_scan_zone", zone)
+}
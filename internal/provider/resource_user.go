@@ -0,0 +1,264 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// userAuthTypeMap maps the auth types used in configuration to the ones SC uses.
+var userAuthTypeMap = map[string]string{
+	"password": "tns",
+	"tls":      "certificate",
+	"ldap":     "ldap",
+	"saml":     "saml",
+}
+
+// userContactFields maps contact detail attributes to their user fields.
+var userContactFields = map[string]func(u *tenablesc.UserBaseFields) *string{
+	"first_name": func(u *tenablesc.UserBaseFields) *string { return &u.Firstname },
+	"last_name":  func(u *tenablesc.UserBaseFields) *string { return &u.Lastname },
+	"title":      func(u *tenablesc.UserBaseFields) *string { return &u.Title },
+	"email":      func(u *tenablesc.UserBaseFields) *string { return &u.Email },
+	"phone":      func(u *tenablesc.UserBaseFields) *string { return &u.Phone },
+	"address":    func(u *tenablesc.UserBaseFields) *string { return &u.Address },
+	"city":       func(u *tenablesc.UserBaseFields) *string { return &u.City },
+	"state":      func(u *tenablesc.UserBaseFields) *string { return &u.State },
+	"country":    func(u *tenablesc.UserBaseFields) *string { return &u.Country },
+}
+
+// userScope requires administrator credentials for users of another organization, given by organization_id.
+func userScope(d scopedData) credentialScope {
+	if d.Get("organization_id").(string) != "" {
+		return scopeAdmin
+	}
+	return scopeOrganization
+}
+
+// ResourceUser Initialize the User Resource
+func ResourceUser() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"username": {
+			Type:        schema.TypeString,
+			Description: descriptionUserUsername,
+			Required:    true,
+		},
+		"auth_type": {
+			Type:             schema.TypeString,
+			Description:      descriptionUserAuthType,
+			Required:         true,
+			ValidateDiagFunc: validateUserAuthType,
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Description: descriptionUserPassword,
+			Optional:    true,
+			Sensitive:   true,
+			StateFunc:   hashSecret,
+		},
+		"ldap_id": {
+			Type:        schema.TypeString,
+			Description: descriptionUserLDAPID,
+			Optional:    true,
+		},
+		"ldap_username": {
+			Type:        schema.TypeString,
+			Description: descriptionUserLDAPUsername,
+			Optional:    true,
+		},
+		"role_id": {
+			Type:        schema.TypeString,
+			Description: descriptionRoleID,
+			Required:    true,
+		},
+		"group_id": {
+			Type:        schema.TypeString,
+			Description: descriptionGroupID,
+			Required:    true,
+		},
+		"organization_id": {
+			Type:        schema.TypeString,
+			Description: descriptionUserOrganizationID,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"responsibility_asset_id": {
+			Type:        schema.TypeString,
+			Description: descriptionUserResponsibilityAssetID,
+			Optional:    true,
+		},
+		"locked": {
+			Type:        schema.TypeBool,
+			Description: descriptionUserLocked,
+			Optional:    true,
+			Default:     false,
+		},
+	}
+
+	for k := range userContactFields {
+		s[k] = &schema.Schema{
+			Type:        schema.TypeString,
+			Description: fmt.Sprintf(descriptionUserContactDetailTemplate, strings.ReplaceAll(k, "_", " ")),
+			Optional:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Description:   descriptionResourceUser,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	user, err := sc.CreateUser(buildUserInput(d, true))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(string(user.ID))
+
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	user, err := sc.GetUser(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	d.SetId(string(user.ID))
+	d.Set("username", user.Username)
+	d.Set("ldap_id", user.LDAPID)
+	d.Set("ldap_username", user.LDAPUsername)
+	d.Set("role_id", user.RoleID)
+	d.Set("group_id", user.GroupID)
+	d.Set("responsibility_asset_id", user.ResponsibleAssetID)
+	d.Set("locked", user.Locked.AsBool())
+
+	// Only track the organization when it was configured; for users managed with organization
+	// credentials it's always the organization of the provider's user.
+	if _, ok := d.GetOk("organization_id"); ok {
+		d.Set("organization_id", user.OrgID)
+	}
+
+	authType := user.AuthType
+	for k, v := range userAuthTypeMap {
+		if v == user.AuthType {
+			authType = k
+		}
+	}
+	d.Set("auth_type", authType)
+
+	for k, field := range userContactFields {
+		d.Set(k, *field(&user.UserBaseFields))
+	}
+
+	return nil
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	_, err := sc.UpdateUser(buildUserInput(d, false))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	err := sc.DeleteUser(d.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, d, err)
+	}
+
+	return nil
+}
+
+// buildUserInput renders the configured user. The password is only sent when creating or when it has
+// changed, as outside of those cases only its hash is known.
+func buildUserInput(d *schema.ResourceData, create bool) *tenablesc.User {
+	user := &tenablesc.User{
+		UserBaseFields: tenablesc.UserBaseFields{
+			ID:           tenablesc.ProbablyString(d.Id()),
+			Username:     d.Get("username").(string),
+			AuthType:     userAuthTypeMap[d.Get("auth_type").(string)],
+			LDAPUsername: d.Get("ldap_username").(string),
+			Locked:       tenablesc.ToFakeBool(d.Get("locked").(bool)),
+		},
+		RoleID:             d.Get("role_id").(string),
+		GroupID:            d.Get("group_id").(string),
+		ResponsibleAssetID: d.Get("responsibility_asset_id").(string),
+		LDAPID:             d.Get("ldap_id").(string),
+	}
+
+	if create {
+		user.OrgID = d.Get("organization_id").(string)
+	}
+
+	// SC uses -1 for 'no asset'; an empty ID would be left out of the request entirely.
+	if user.ResponsibleAssetID == "" && d.HasChange("responsibility_asset_id") {
+		user.ResponsibleAssetID = "-1"
+	}
+
+	if create || d.HasChange("password") {
+		user.Password = d.Get("password").(string)
+	}
+
+	for k, field := range userContactFields {
+		*field(&user.UserBaseFields) = d.Get(k).(string)
+	}
+
+	return user
+}
+
+func validateUserAuthType(authType any, path cty.Path) (diags diag.Diagnostics) {
+	if _, ok := userAuthTypeMap[authType.(string)]; !ok {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("unsupported auth type %q; must be one of password, tls, ldap, saml", authType),
+			AttributePath: path,
+		})
+	}
+	return
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestResourceUserLifecycle(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"username":   "jdoe",
+		"auth_type":  "password",
+		"password":   "hunter2",
+		"role_id":    "3",
+		"group_id":   "0",
+		"first_name": "Jane",
+		"email":      "jdoe@example.com",
+	}
+
+	state := h.apply("tenablesc_user", nil, config)
+	requireAttribute(t, state, "auth_type", "password")
+	requireAttribute(t, state, "role_id", "3")
+	requireAttribute(t, state, "group_id", "0")
+	requireAttribute(t, state, "responsibility_asset_id", "")
+	requireAttribute(t, state, "locked", "false")
+	requireAttribute(t, state, "first_name", "Jane")
+	requireAttribute(t, state, "password", hashSecret("hunter2"))
+	requireNoPlaintext(t, state.Attributes, "hunter2")
+	h.requireEmptyPlan("tenablesc_user", state, config)
+
+	stored := h.server.Get(fakesc.EndpointUser, state.ID)
+	if stored["password"] != "hunter2" || stored["authType"] != "tns" {
+		t.Fatalf("expected password user to be sent to SC, got %v", stored)
+	}
+
+	// Unrelated updates must not resend the password, which is only known as a hash.
+	h.server.Update(fakesc.EndpointUser, state.ID, fakesc.Object{"password": "changed in UI"})
+	config["locked"] = true
+	config["responsibility_asset_id"] = "12"
+	delete(config, "email")
+	state = h.apply("tenablesc_user", state, config)
+	requireAttribute(t, state, "locked", "true")
+	requireAttribute(t, state, "responsibility_asset_id", "12")
+	requireAttribute(t, state, "email", "")
+	if stored := h.server.Get(fakesc.EndpointUser, state.ID); stored["password"] != "changed in UI" {
+		t.Fatalf("expected password not to be resent, got %v", stored["password"])
+	}
+
+	config["password"] = "correct horse"
+	delete(config, "responsibility_asset_id")
+	state = h.apply("tenablesc_user", state, config)
+	requireAttribute(t, state, "password", hashSecret("correct horse"))
+	requireAttribute(t, state, "responsibility_asset_id", "")
+	if stored := h.server.Get(fakesc.EndpointUser, state.ID); stored["password"] != "correct horse" {
+		t.Fatalf("expected password to be updated, got %v", stored["password"])
+	}
+
+	imported := h.importState("tenablesc_user", state.ID)
+	requireAttribute(t, imported, "username", "jdoe")
+	requireAttribute(t, imported, "auth_type", "password")
+	requireAttribute(t, imported, "locked", "true")
+
+	h.destroy("tenablesc_user", state)
+	if h.server.Count(fakesc.EndpointUser) != 0 {
+		t.Fatal("expected user to be deleted")
+	}
+}
+
+func TestResourceUserInOrganization(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := map[string]interface{}{
+		"username":        "ldapuser",
+		"auth_type":       "ldap",
+		"ldap_id":         "2",
+		"ldap_username":   "ldapuser@example.com",
+		"role_id":         "1",
+		"group_id":        "0",
+		"organization_id": "4",
+	}
+
+	state := h.apply("tenablesc_user", nil, config)
+	requireAttribute(t, state, "organization_id", "4")
+	requireAttribute(t, state, "ldap_id", "2")
+	h.requireEmptyPlan("tenablesc_user", state, config)
+
+	config["organization_id"] = "5"
+	if diff := h.plan("tenablesc_user", state, config); !diff.RequiresNew() {
+		t.Fatal("expected changing organization to replace the user")
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// riskRuleSet manages rules of a kind in a repository as a set of rule blocks: those it created, and
+// those in the repository when it was imported. Other rules added to the repository are left alone.
+// State tracks the SC ID of each block in rule_ids, keyed by the block's set hash, so reads
+// list the rules once and applies create and delete only the blocks that changed.
+type riskRuleSet struct {
+	kind string
+	// rule is the schema of a single rule block.
+	rule *schema.Resource
+	// list returns every rule of the kind in SC, flattened to rule blocks.
+	list func(sc *tenablesc.Client) ([]riskRuleSetMember, error)
+	// create creates the rule a block describes and returns its ID.
+	create func(sc *tenablesc.Client, repositoryID string, rule map[string]interface{}) (string, error)
+	remove func(sc *tenablesc.Client, id string) error
+}
+
+type riskRuleSetMember struct {
+	id           string
+	repositoryID string
+	rule         map[string]interface{}
+}
+
+func (s riskRuleSet) resource(description string) *schema.Resource {
+	return &schema.Resource{
+		Description:   description,
+		CreateContext: s.createContext,
+		ReadContext:   s.readContext,
+		UpdateContext: s.updateContext,
+		DeleteContext: s.deleteContext,
+		CustomizeDiff: customizeRiskRuleSetDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: s.importContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:        schema.TypeString,
+				Description: descriptionRepositoryID,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Description: descriptionRiskRuleSetRule,
+				Optional:    true,
+				Elem:        s.rule,
+			},
+			"rule_ids": {
+				Type:        schema.TypeMap,
+				Description: descriptionRiskRuleSetRuleIDs,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func (s riskRuleSet) key(rule interface{}) string {
+	return strconv.Itoa(schema.HashResource(s.rule)(rule))
+}
+
+func (s riskRuleSet) createContext(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+
+	d.SetId(d.Get("repository_id").(string))
+
+	return s.reconcile(ctx, d, m)
+}
+
+func (s riskRuleSet) updateContext(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+
+	return s.reconcile(ctx, d, m)
+}
+
+// reconcile creates the rules for blocks state does not track yet, then deletes the rules of blocks removed
+// from the configuration. Changed blocks are new blocks, so their replacements exist before the old rules go.
+func (s riskRuleSet) reconcile(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	sc := m.(*tenablesc.Client)
+
+	// rule_ids is unknown in the plan whenever the blocks change; the prior state holds the tracked rules.
+	tracked, _ := d.GetChange("rule_ids")
+	ruleIDs := map[string]interface{}{}
+	for key, id := range tracked.(map[string]interface{}) {
+		ruleIDs[key] = id
+	}
+
+	configured := map[string]bool{}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		key := s.key(rule)
+		configured[key] = true
+		if _, ok := ruleIDs[key]; ok {
+			continue
+		}
+
+		id, err := s.create(sc, d.Id(), rule.(map[string]interface{}))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("failed to create %s rule for plugin %s", s.kind, rule.(map[string]interface{})["plugin_id"]),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		logInfo(ctx, "created "+s.kind+" rule", map[string]interface{}{logFieldObjectID: id})
+		ruleIDs[key] = id
+	}
+
+	// Keep the rules being replaced until every replacement exists.
+	if !diags.HasError() {
+		for key, id := range ruleIDs {
+			if configured[key] {
+				continue
+			}
+			if err := s.remove(sc, id.(string)); err != nil && !errors.As(err, &tenablesc.NotFoundError{}) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("failed to delete %s rule %s", s.kind, id),
+					Detail:   fmt.Sprintf("The rule still applies in SC, and is deleted by the next apply: %s", err),
+				})
+				continue
+			}
+			logInfo(ctx, "deleted "+s.kind+" rule", map[string]interface{}{logFieldObjectID: id})
+			delete(ruleIDs, key)
+		}
+	}
+
+	d.Set("rule_ids", ruleIDs)
+
+	return append(diags, s.readContext(ctx, d, m)...)
+}
+
+func (s riskRuleSet) readContext(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	members, err := s.list(sc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	inRepository := map[string]riskRuleSetMember{}
+	for _, member := range members {
+		if member.repositoryID == d.Id() {
+			inRepository[member.id] = member
+		}
+	}
+
+	known := map[string]interface{}{}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		known[s.key(rule)] = rule
+	}
+
+	ruleIDs := map[string]interface{}{}
+	var rules []interface{}
+	for key, id := range d.Get("rule_ids").(map[string]interface{}) {
+		member, ok := inRepository[id.(string)]
+		if !ok {
+			logInfo(ctx, s.kind+" rule no longer exists, assuming it has been deleted", map[string]interface{}{logFieldObjectID: id})
+			continue
+		}
+
+		// Keep the block as written while SC still has an equivalent rule; SC normalizes some values.
+		var rule interface{} = member.rule
+		if block, ok := known[key]; ok && s.equivalent(block.(map[string]interface{}), member.rule) {
+			rule = block
+		}
+		ruleIDs[s.key(rule)] = member.id
+		rules = append(rules, rule)
+	}
+
+	d.Set("repository_id", d.Id())
+	d.Set("rule", rules)
+	d.Set("rule_ids", ruleIDs)
+
+	return nil
+}
+
+func (s riskRuleSet) deleteContext(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	logTrace(ctx, "start of function")
+	sc := m.(*tenablesc.Client)
+
+	for _, id := range d.Get("rule_ids").(map[string]interface{}) {
+		if err := s.remove(sc, id.(string)); err != nil && !errors.As(err, &tenablesc.NotFoundError{}) {
+			diags = append(diags, diag.FromErr(fmt.Errorf("failed to delete %s rule %s: %w", s.kind, id, err))...)
+		}
+	}
+
+	return
+}
+
+// importContext takes a repository ID, and adopts every rule of the kind in that repository.
+// Identical rules would be the same block, so the repository must not have any.
+func (s riskRuleSet) importContext(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sc := m.(*tenablesc.Client)
+
+	members, err := s.list(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleIDs := map[string]interface{}{}
+	for _, member := range members {
+		if member.repositoryID != d.Id() {
+			continue
+		}
+		key := s.key(member.rule)
+		if id, ok := ruleIDs[key]; ok {
+			return nil, fmt.Errorf("%s rules %s and %s in repository %s are identical; delete one of them before importing", s.kind, id, member.id, d.Id())
+		}
+		ruleIDs[key] = member.id
+	}
+	d.Set("rule_ids", ruleIDs)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// equivalent compares a rule block against the one flattened from SC, allowing for how SC normalizes values.
+func (s riskRuleSet) equivalent(block, actual map[string]interface{}) bool {
+	for name := range s.rule.Schema {
+		configured, _ := block[name].(string)
+		current, _ := actual[name].(string)
+
+		switch {
+		case configured == current:
+		case name == "host_value" && diffSuppressNormalizedIPSet(name, current, configured, nil):
+		case name == "protocol" && riskRuleProtocolID(configured) == riskRuleProtocolID(current):
+		case name == "expiration" && DiffSuppressParsedTimes(name, current, configured, nil):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func customizeRiskRuleSetDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.HasChange("rule") {
+		return d.SetNewComputed("rule_ids")
+	}
+	return nil
+}
+
+// riskRuleProtocolID returns the SC protocol ID for a protocol name, or the value itself if it already is one.
+func riskRuleProtocolID(protocol string) string {
+	if id, err := getRecastAcceptRiskProtocolID(protocol); err == nil {
+		return id
+	}
+	return protocol
+}
+
+// riskRuleProtocolName maps SC protocol IDs back to the names configurations use.
+func riskRuleProtocolName(protocol string) string {
+	for name, id := range RecastAcceptRiskProtocolIDMap {
+		if id == protocol {
+			return name
+		}
+	}
+	return protocol
+}
+
+// riskRuleHostValue strips the quotes SC returns around IP and UUID host values.
+func riskRuleHostValue(hostValue string) string {
+	if unquoted, err := strconv.Unquote(hostValue); err == nil {
+		return unquoted
+	}
+	return hostValue
+}
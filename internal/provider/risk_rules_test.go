@@ -0,0 +1,166 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestRiskRuleSetsReconcileDifferences(t *testing.T) {
+	for _, resourceType := range []string{"tenablesc_accept_risk_rules", "tenablesc_recast_risk_rules"} {
+		t.Run(resourceType, func(t *testing.T) {
+			h := newTestHarness(t)
+
+			repositoryID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"})
+			endpoint := fakesc.EndpointAcceptRiskRule
+			rules := []interface{}{
+				map[string]interface{}{"plugin_id": "19506", "host_type": "ip", "host_value": "10.0.0.1,10.0.0.2", "protocol": "TCP", "comments": "scanner hosts"},
+				map[string]interface{}{"plugin_id": "10180", "comments": "ping"},
+				map[string]interface{}{"plugin_id": "11219", "port": "443"},
+			}
+			if resourceType == "tenablesc_recast_risk_rules" {
+				endpoint = fakesc.EndpointRecastRiskRule
+				rules[1].(map[string]interface{})["new_severity"] = "1"
+			} else {
+				rules[1].(map[string]interface{})["expiration"] = time.Now().Add(24 * time.Hour).UTC().Format(timeLayout)
+			}
+			config := map[string]interface{}{"repository_id": repositoryID, "rule": rules}
+
+			// rules the set doesn't manage are left alone.
+			unmanaged := h.apply(strings.TrimSuffix(resourceType, "_rules"), nil, map[string]interface{}{"repository_id": repositoryID, "plugin_id": "19506"})
+
+			state := h.apply(resourceType, nil, config)
+			requireAttribute(t, state, "id", repositoryID)
+			requireAttribute(t, state, "rule.#", "3")
+			requireAttribute(t, state, "rule_ids.%", "3")
+			if h.server.Count(endpoint) != 4 {
+				t.Fatalf("expected 3 rules besides the unmanaged one, got %d rules", h.server.Count(endpoint))
+			}
+			h.requireEmptyPlan(resourceType, state, config)
+
+			before := len(h.server.Requests())
+			state = h.refresh(resourceType, state)
+			if reads := h.server.Requests()[before:]; len(reads) != 1 || reads[0] != "GET /"+endpoint {
+				t.Fatalf("expected a refresh to list the rules once, got %v", reads)
+			}
+
+			// changing one block replaces only its rule, creating the replacement first.
+			var replaced string
+			for key, id := range state.Attributes {
+				if strings.HasPrefix(key, "rule_ids.") && h.server.Get(endpoint, id) != nil && h.server.Get(endpoint, id)["port"] == "443" {
+					replaced = id
+				}
+			}
+			rules[2].(map[string]interface{})["port"] = "8443"
+			before = len(h.server.Requests())
+			state = h.apply(resourceType, state, config)
+			var writes []string
+			for _, r := range h.server.Requests()[before:] {
+				if !strings.HasPrefix(r, "GET") {
+					writes = append(writes, r)
+				}
+			}
+			if len(writes) != 2 || writes[0] != "POST /"+endpoint || writes[1] != "DELETE /"+endpoint+"/"+replaced {
+				t.Fatalf("expected rule %s to be replaced by a single create and delete, got %v", replaced, writes)
+			}
+			h.requireEmptyPlan(resourceType, state, config)
+
+			// a failed create keeps the rule being replaced.
+			rules[2].(map[string]interface{})["port"] = "9443"
+			h.server.FailNextRequest(http.MethodPost, http.StatusBadRequest, fakesc.ErrorCodeInvalidInput, "Invalid rule")
+			diags := h.applyExpectError(resourceType, state, config)
+			if !strings.Contains(diags[0].Summary, "failed to create") {
+				t.Fatalf("expected an error about the failed create, got %+v", diags)
+			}
+			if h.server.Count(endpoint) != 4 {
+				t.Fatalf("expected the rules to be unchanged, got %d rules", h.server.Count(endpoint))
+			}
+			state = h.apply(resourceType, state, config)
+
+			// rules deleted in SC are created again.
+			for key, id := range state.Attributes {
+				if strings.HasPrefix(key, "rule_ids.") && h.server.Get(endpoint, id) != nil && h.server.Get(endpoint, id)["port"] == "9443" {
+					h.server.Remove(endpoint, id)
+				}
+			}
+			state = h.apply(resourceType, h.refresh(resourceType, state), config)
+			requireAttribute(t, state, "rule.#", "3")
+			h.requireEmptyPlan(resourceType, state, config)
+
+			imported := h.importState(resourceType, repositoryID)
+			requireAttribute(t, imported, "rule.#", "4")
+
+			h.destroy(resourceType, state)
+			if h.server.Count(endpoint) != 1 || h.server.Get(endpoint, unmanaged.ID) == nil {
+				t.Fatalf("expected only the unmanaged rule %s to remain, got %d rules", unmanaged.ID, h.server.Count(endpoint))
+			}
+		})
+	}
+}
+
+func TestRiskRuleSetImport(t *testing.T) {
+	h := newTestHarness(t)
+
+	repositoryID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "repo", "type": "Local", "dataFormat": "IPv4"})
+	otherID := h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "other", "type": "Local", "dataFormat": "IPv4"})
+	for _, repository := range []string{repositoryID, otherID} {
+		h.apply("tenablesc_accept_risk", nil, map[string]interface{}{
+			"repository_id": repository,
+			"plugin_id":     "19506",
+			"host_type":     "ip",
+			"host_value":    "10.0.0.1,10.0.0.2",
+			"protocol":      "udp",
+			"expiration":    time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC).Format(timeLayout),
+			"comments":      "accepted",
+		})
+	}
+
+	imported := h.importState("tenablesc_accept_risk_rules", repositoryID)
+	requireAttribute(t, imported, "repository_id", repositoryID)
+	requireAttribute(t, imported, "rule.#", "1")
+
+	h.requireEmptyPlan("tenablesc_accept_risk_rules", imported, map[string]interface{}{
+		"repository_id": repositoryID,
+		"rule": []interface{}{map[string]interface{}{
+			"plugin_id":  "19506",
+			"host_type":  "ip",
+			"host_value": "10.0.0.1,10.0.0.2",
+			"protocol":   "udp",
+			"expiration": "2100-01-01T00:00:00Z",
+			"comments":   "accepted",
+		}},
+	})
+
+	// identical rules can't be told apart as blocks.
+	h.apply("tenablesc_accept_risk", nil, map[string]interface{}{
+		"repository_id": repositoryID,
+		"plugin_id":     "19506",
+		"host_type":     "ip",
+		"host_value":    "10.0.0.1,10.0.0.2",
+		"protocol":      "udp",
+		"expiration":    time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC).Format(timeLayout),
+		"comments":      "accepted",
+	})
+	_, err := h.provider.ImportState(h.ctx, &terraform.InstanceInfo{Type: "tenablesc_accept_risk_rules"}, repositoryID)
+	if err == nil || !strings.Contains(err.Error(), "are identical") {
+		t.Fatalf("expected importing identical rules to fail, got %v", err)
+	}
+}
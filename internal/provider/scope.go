@@ -0,0 +1,150 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// credentialScope is the kind of SC credentials an object is managed with.
+// SC only lets administrators (org=0) manage some objects, and organization users the others.
+type credentialScope int
+
+const (
+	// scopeAny objects can be managed with either kind of credentials.
+	scopeAny credentialScope = iota
+	scopeAdmin
+	scopeOrganization
+)
+
+// String returns the name of the provider block holding credentials of the scope.
+func (s credentialScope) String() string {
+	switch s {
+	case scopeAdmin:
+		return "admin"
+	case scopeOrganization:
+		return "organization"
+	default:
+		return "any"
+	}
+}
+
+// check returns an error if user is not of the scope.
+func (s credentialScope) check(user *tenablesc.CurrentUser) error {
+	admin := isAdminUser(user)
+	switch {
+	case s == scopeAdmin && !admin:
+		return fmt.Errorf("admin credentials belong to user %s of organization %s, not an administrator", user.Username, user.Organization.ID)
+	case s == scopeOrganization && admin:
+		return fmt.Errorf("organization credentials belong to administrator %s, not an organization user", user.Username)
+	}
+	return nil
+}
+
+// Administrators are not members of any organization, shown as org 0.
+func isAdminUser(user *tenablesc.CurrentUser) bool {
+	return user.Organization.ID == "" || user.Organization.ID == "0"
+}
+
+// providerClients is the meta of a configured provider, holding a client for each set of credentials configured.
+type providerClients struct {
+	// defaultClient uses the top-level credentials, which may be of either scope.
+	defaultClient *tenablesc.Client
+	admin         *tenablesc.Client
+	organization  *tenablesc.Client
+
+	writes *writeLimits
+}
+
+// client returns the client to manage objects of scope with. Scopes without their own credential block
+// fall back to the top-level credentials, so a single-scope configuration works as it always has.
+func (p *providerClients) client(scope credentialScope) (*tenablesc.Client, error) {
+	var scoped *tenablesc.Client
+	switch scope {
+	case scopeAdmin:
+		scoped = p.admin
+	case scopeOrganization:
+		scoped = p.organization
+	default:
+		for _, c := range []*tenablesc.Client{p.defaultClient, p.organization, p.admin} {
+			if c != nil {
+				return c, nil
+			}
+		}
+	}
+
+	if scoped != nil {
+		return scoped, nil
+	}
+	if p.defaultClient != nil {
+		return p.defaultClient, nil
+	}
+	return nil, fmt.Errorf("%s credentials are required; configure them in the provider's %s block", scope, scope)
+}
+
+// scopedData is the configuration or state a scopeFunc decides on.
+type scopedData interface {
+	Get(key string) interface{}
+}
+
+// scopeFunc returns the scope an operation on a resource needs, for resources where that varies.
+type scopeFunc func(d scopedData) credentialScope
+
+// withScope has the operations of r receive the client for scope as their meta, instead of providerClients.
+// The client is bound to the context of the operation, so Terraform cancelling it or its timeout
+// stops the SC requests the operation makes.
+func withScope(scope credentialScope, r *schema.Resource) *schema.Resource {
+	return withScopeFunc(func(scopedData) credentialScope { return scope }, r)
+}
+
+// withScopeFunc is withScope for resources where the scope depends on their configuration.
+// CustomizeDiff functions are not wrapped; they must not use their meta.
+func withScopeFunc(scope scopeFunc, r *schema.Resource) *schema.Resource {
+	r.CreateContext = scoped(scope, r.CreateContext)
+	r.ReadContext = scoped(scope, r.ReadContext)
+	r.UpdateContext = scoped(scope, r.UpdateContext)
+	r.DeleteContext = scoped(scope, r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importer := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			client, err := m.(*providerClients).client(scope(d))
+			if err != nil {
+				return nil, err
+			}
+			return importer(ctx, d, client.WithContext(ctx))
+		}
+	}
+
+	return r
+}
+
+func scoped[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](scope scopeFunc, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client, err := m.(*providerClients).client(scope(d))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, client.WithContext(ctx))
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+var (
+	fakeAdminUser = fakesc.Object{"id": "2", "username": "admin", "organization": fakesc.Object{"id": "0"}}
+	fakeOrgUser   = fakesc.Object{"id": "3", "username": "manager", "organization": fakesc.Object{"id": "1", "name": "Fake Organization"}}
+)
+
+// scopedProviderConfig returns a provider configuration holding only the credential blocks given.
+func scopedProviderConfig(h *testHarness, admin, organization bool) map[string]interface{} {
+	h.server.AddAPIKey("admin-access", "admin-secret", fakeAdminUser)
+	h.server.AddAPIKey("org-access", "org-secret", fakeOrgUser)
+
+	config := map[string]interface{}{"uri": h.server.URI()}
+	if admin {
+		config["admin"] = []interface{}{map[string]interface{}{"access_key": "admin-access", "secret_key": "admin-secret"}}
+	}
+	if organization {
+		config["organization"] = []interface{}{map[string]interface{}{"access_key": "org-access", "secret_key": "org-secret"}}
+	}
+	return config
+}
+
+func TestDualScopeProvider(t *testing.T) {
+	h := newTestHarness(t)
+	h.provider = Provider()
+	requireNoErrors(t, "configure provider",
+		h.provider.Configure(h.ctx, terraform.NewResourceConfigRaw(scopedProviderConfig(h, true, true))))
+
+	// the fake rejects each of these with credentials of the other scope.
+	zone := h.apply("tenablesc_scan_zone", nil, map[string]interface{}{
+		"name":       "datacenter",
+		"zone_cidrs": []interface{}{"10.0.0.0/8"},
+	})
+	asset := h.apply("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "datacenter",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.1"},
+	})
+
+	h.server.Seed(fakesc.EndpointRepository, fakesc.Object{"name": "datacenter", "type": "Local", "dataFormat": "IPv4"})
+	_, diags := h.readDataSource("tenablesc_repositories", map[string]interface{}{})
+	requireNoErrors(t, "read repositories", diags)
+
+	h.destroy("tenablesc_asset", asset)
+	h.destroy("tenablesc_scan_zone", zone)
+}
+
+func TestSingleScopeProviderRequiresOtherScope(t *testing.T) {
+	h := newTestHarness(t)
+	h.provider = Provider()
+	requireNoErrors(t, "configure provider",
+		h.provider.Configure(h.ctx, terraform.NewResourceConfigRaw(scopedProviderConfig(h, true, false))))
+
+	h.apply("tenablesc_scan_zone", nil, map[string]interface{}{
+		"name":       "datacenter",
+		"zone_cidrs": []interface{}{"10.0.0.0/8"},
+	})
+
+	diags := h.applyExpectError("tenablesc_asset", nil, map[string]interface{}{
+		"name":   "datacenter",
+		"type":   "static",
+		"values": []interface{}{"10.0.0.1"},
+	})
+	if len(diags) != 1 || diags[0].Summary != "organization credentials are required; configure them in the provider's organization block" {
+		t.Fatalf("expected an error asking for organization credentials, got %+v", diags)
+	}
+}
+
+func TestScopedCredentialsAreValidated(t *testing.T) {
+	h := newTestHarness(t)
+
+	config := scopedProviderConfig(h, false, false)
+	config["admin"] = []interface{}{map[string]interface{}{"access_key": "org-access", "secret_key": "org-secret"}}
+	if diags := Provider().Configure(h.ctx, terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Error("expected organization keys to be rejected as admin credentials")
+	}
+
+	config = scopedProviderConfig(h, false, false)
+	config["organization"] = []interface{}{map[string]interface{}{"access_key": "admin-access", "secret_key": "admin-secret"}}
+	if diags := Provider().Configure(h.ctx, terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Error("expected admin keys to be rejected as organization credentials")
+	}
+
+	config = scopedProviderConfig(h, true, false)
+	config["organization"] = []interface{}{map[string]interface{}{"access_key": "org-access", "secret_key": "wrong"}}
+	if diags := Provider().Configure(h.ctx, terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Error("expected invalid organization keys to be rejected")
+	}
+}
+
+func TestOperationsHonorContextCancellation(t *testing.T) {
+	h := newTestHarness(t)
+
+	state := h.apply("tenablesc_group", nil, map[string]interface{}{"name": "analysts"})
+
+	h.server.SetLatency(time.Minute)
+
+	ctx, cancel := context.WithTimeout(h.ctx, 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, diags := h.resource("tenablesc_group").RefreshWithoutUpgrade(ctx, state, h.provider.Meta())
+	if !diags.HasError() {
+		t.Fatal("expected refresh to fail once its context is done")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected refresh to stop at its deadline, took %s", elapsed)
+	}
+}
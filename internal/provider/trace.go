@@ -0,0 +1,96 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/palantir/terraform-provider-tenablesc/internal/tenablesc"
+)
+
+// Environment variables to debug SC-specific behavior offline with: TENABLESC_HTTP_TRACE records every
+// request made to SC, and the response to it, to a file; TENABLESC_HTTP_REPLAY answers requests from
+// such a file instead of SC.
+const (
+	envHTTPTrace  = "TENABLESC_HTTP_TRACE"
+	envHTTPReplay = "TENABLESC_HTTP_REPLAY"
+)
+
+// Traces are shared by every provider configured in the plugin process, so a trace of a configuration
+// with provider aliases replays in the order the requests were made.
+var (
+	tracesMu       sync.Mutex
+	traceRecorders = map[string]*tenablesc.TraceRecorder{}
+	traceReplayers = map[string]*tenablesc.TraceReplayer{}
+)
+
+// withHTTPTrace returns a function setting up clients to record or replay the HTTP traces the
+// environment asks for, warning about either.
+func withHTTPTrace() (func(*tenablesc.Client) *tenablesc.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tracesMu.Lock()
+	defer tracesMu.Unlock()
+
+	var recorder *tenablesc.TraceRecorder
+	if path := os.Getenv(envHTTPTrace); path != "" {
+		if recorder = traceRecorders[path]; recorder == nil {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				return nil, diag.Errorf("failed to open %s file: %s", envHTTPTrace, err)
+			}
+			recorder = tenablesc.NewTraceRecorder(f)
+			traceRecorders[path] = recorder
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "recording SC requests to " + path,
+			Detail:   "Credentials are masked in the trace, but it holds the configuration SC returned. Review it before sharing.",
+		})
+	}
+
+	var replayer *tenablesc.TraceReplayer
+	if path := os.Getenv(envHTTPReplay); path != "" {
+		if replayer = traceReplayers[path]; replayer == nil {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, diag.Errorf("failed to open %s file: %s", envHTTPReplay, err)
+			}
+			defer f.Close()
+
+			if replayer, err = tenablesc.NewTraceReplayer(f); err != nil {
+				return nil, diag.Errorf("failed to load %s file: %s", envHTTPReplay, err)
+			}
+			traceReplayers[path] = replayer
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "replaying SC requests from " + path,
+			Detail:   "SC is not contacted; requests are answered from the trace, and fail once it has no response left for them.",
+		})
+	}
+
+	return func(client *tenablesc.Client) *tenablesc.Client {
+		if recorder != nil {
+			client.RecordTrace(recorder)
+		}
+		if replayer != nil {
+			client.ReplayTrace(replayer)
+		}
+		return client
+	}, diags
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/palantir/terraform-provider-tenablesc/internal/fakesc"
+)
+
+func TestHTTPTraceRecordAndReplay(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	config := map[string]interface{}{
+		"username":  "jdoe",
+		"auth_type": "password",
+		"password":  "hunter2",
+		"role_id":   "3",
+		"group_id":  "0",
+	}
+
+	t.Setenv(envHTTPTrace, tracePath)
+	recorded := newTestHarness(t)
+	recordedState := recorded.apply("tenablesc_user", nil, config)
+
+	trace, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("failed to read trace: %s", err)
+	}
+	for _, secret := range []string{"hunter2", fakesc.DefaultAccessKey, fakesc.DefaultSecretKey} {
+		if strings.Contains(string(trace), secret) {
+			t.Fatalf("expected %q to be masked in the trace:\n%s", secret, trace)
+		}
+	}
+	if !strings.Contains(string(trace), `"endpoint":"/user"`) {
+		t.Fatalf("expected the trace to record the user created:\n%s", trace)
+	}
+
+	// The replay must not need SC at all.
+	t.Setenv(envHTTPTrace, "")
+	t.Setenv(envHTTPReplay, tracePath)
+	replayed := newTestHarness(t)
+	replayed.server.Close()
+
+	replayedState := replayed.apply("tenablesc_user", nil, config)
+	if replayedState.ID != recordedState.ID {
+		t.Fatalf("expected the replay to create user %s, got %s", recordedState.ID, replayedState.ID)
+	}
+	requireAttribute(t, replayedState, "username", "jdoe")
+
+	// Once the trace is used up, requests fail instead of reaching SC.
+	diags := replayed.applyExpectError("tenablesc_user", nil, config)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "trace has no response left") {
+		t.Fatalf("expected an exhausted trace to fail the apply, got %v", diags)
+	}
+}
+
+func TestHTTPTraceMasksCredentialSecrets(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	t.Setenv(envHTTPTrace, tracePath)
+
+	h := newTestHarness(t)
+	h.apply("tenablesc_credential", nil, map[string]interface{}{
+		"name": "snmp",
+		"snmp": []interface{}{
+			map[string]interface{}{"community_string": "plain-community"},
+		},
+	})
+
+	trace, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("failed to read trace: %s", err)
+	}
+	if !strings.Contains(string(trace), `"method":"POST","endpoint":"/credential"`) {
+		t.Fatalf("expected the trace to record the credential created:\n%s", trace)
+	}
+	if strings.Contains(string(trace), "plain-community") {
+		t.Fatalf("expected the community string to be masked in the trace:\n%s", trace)
+	}
+}
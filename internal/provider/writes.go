@@ -0,0 +1,153 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// writtenObjectTypes are the SC object types the writes of each resource change, for serialized_object_types.
+// Associations change the object they associate others with, so they serialize with the resource managing it.
+// tenablesc_scan_launch is missing on purpose: it writes once, but then waits for the scan to finish.
+var writtenObjectTypes = map[string]string{
+	"tenablesc_accept_risk":                         "accept_risk_rule",
+	"tenablesc_accept_risk_rules":                   "accept_risk_rule",
+	"tenablesc_agent_scan":                          "scan",
+	"tenablesc_asset":                               "asset",
+	"tenablesc_auditfile":                           "audit_file",
+	"tenablesc_credential":                          "credential",
+	"tenablesc_group":                               "group",
+	"tenablesc_organization":                        "organization",
+	"tenablesc_recast_risk":                         "recast_risk_rule",
+	"tenablesc_recast_risk_rules":                   "recast_risk_rule",
+	"tenablesc_repository":                          "repository",
+	"tenablesc_scan_policy":                         "scan_policy",
+	"tenablesc_scan":                                "scan",
+	"tenablesc_scan_zone":                           "scan_zone",
+	"tenablesc_repository_organization_association": "repository",
+	"tenablesc_organization_scan_zone_association":  "organization",
+	"tenablesc_role":                                "role",
+	"tenablesc_user":                                "user",
+}
+
+// writeLimits bounds the writes a configured provider makes to SC at once. SC locks its database for writes,
+// and answers concurrent ones with "database is locked".
+type writeLimits struct {
+	// slots holds a token for every write in progress; nil means writes are not limited.
+	slots chan struct{}
+	// serialized holds a lock for each object type written one at a time.
+	serialized map[string]chan struct{}
+}
+
+func newWriteLimits(maxConcurrentWrites int, serializedObjectTypes []string) *writeLimits {
+	w := &writeLimits{serialized: map[string]chan struct{}{}}
+	if maxConcurrentWrites > 0 {
+		w.slots = make(chan struct{}, maxConcurrentWrites)
+	}
+	for _, objectType := range serializedObjectTypes {
+		w.serialized[objectType] = make(chan struct{}, 1)
+	}
+	return w
+}
+
+// acquire waits for the turn of a write to objectType, returning the function to call once it is done.
+// The lock of the object type is taken first, so writes waiting on it don't hold a slot meanwhile.
+func (w *writeLimits) acquire(ctx context.Context, objectType string) (func(), error) {
+	var held []chan struct{}
+	release := func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			<-held[i]
+		}
+	}
+
+	for _, lock := range []chan struct{}{w.serialized[objectType], w.slots} {
+		if lock == nil {
+			continue
+		}
+		select {
+		case lock <- struct{}{}:
+			held = append(held, lock)
+		case <-ctx.Done():
+			release()
+			return nil, fmt.Errorf("gave up waiting to write to SC: %w", ctx.Err())
+		}
+	}
+
+	return release, nil
+}
+
+// withWriteLimits has the writes of p's resources wait for their turn under the provider's write limits.
+// It must wrap the resources after withScope, which replaces the meta the limits are read from.
+func withWriteLimits(p *schema.Provider) *schema.Provider {
+	for resourceType, r := range p.ResourcesMap {
+		objectType, ok := writtenObjectTypes[resourceType]
+		if !ok {
+			continue
+		}
+		r.CreateContext = limited(objectType, r.CreateContext)
+		r.UpdateContext = limited(objectType, r.UpdateContext)
+		r.DeleteContext = limited(objectType, r.DeleteContext)
+	}
+	return p
+}
+
+func limited[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](objectType string, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		release, err := m.(*providerClients).writes.acquire(ctx, objectType)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer release()
+
+		return f(ctx, d, m)
+	}
+}
+
+// serializableObjectTypes returns the object types serialized_object_types accepts.
+func serializableObjectTypes() []string {
+	types := map[string]bool{}
+	for _, objectType := range writtenObjectTypes {
+		types[objectType] = true
+	}
+
+	sorted := make([]string, 0, len(types))
+	for objectType := range types {
+		sorted = append(sorted, objectType)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func validateSerializableObjectType(i interface{}, path cty.Path) diag.Diagnostics {
+	for _, objectType := range serializableObjectTypes() {
+		if i.(string) == objectType {
+			return nil
+		}
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("unknown object type %q; expected one of %v", i, serializableObjectTypes()),
+		AttributePath: path,
+	}}
+}
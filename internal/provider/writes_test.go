@@ -0,0 +1,118 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// newWriteLimitedHarness returns a harness whose provider is configured with extra, and whose fake SC is slow enough
+// for concurrent writes to overlap.
+func newWriteLimitedHarness(t *testing.T, extra map[string]interface{}) *testHarness {
+	h := newTestHarness(t)
+
+	config := h.providerConfig()
+	for k, v := range extra {
+		config[k] = v
+	}
+	h.provider = Provider()
+	requireNoErrors(t, "configure provider", h.provider.Configure(h.ctx, terraform.NewResourceConfigRaw(config)))
+
+	h.server.SetLatency(20 * time.Millisecond)
+	return h
+}
+
+// createConcurrently creates count objects of each resource type at once, named after their index.
+func createConcurrently(h *testHarness, count int, configs map[string]map[string]interface{}) {
+	h.t.Helper()
+
+	var wg sync.WaitGroup
+	results := make(chan diag.Diagnostics, count*len(configs))
+	for resourceType, config := range configs {
+		for i := 0; i < count; i++ {
+			named := map[string]interface{}{"name": fmt.Sprintf("%s %d", resourceType, i)}
+			for k, v := range config {
+				named[k] = v
+			}
+			diff := h.plan(resourceType, nil, named)
+
+			wg.Add(1)
+			go func(r string) {
+				defer wg.Done()
+				_, diags := h.resource(r).Apply(h.ctx, nil, diff, h.provider.Meta())
+				results <- diags
+			}(resourceType)
+		}
+	}
+	wg.Wait()
+	close(results)
+
+	for diags := range results {
+		requireNoErrors(h.t, "concurrent create", diags)
+	}
+}
+
+var concurrentWriteConfigs = map[string]map[string]interface{}{
+	"tenablesc_group": {},
+	"tenablesc_asset": {"type": "static", "values": []interface{}{"10.0.0.1"}},
+}
+
+func TestMaxConcurrentWrites(t *testing.T) {
+	h := newWriteLimitedHarness(t, map[string]interface{}{"max_concurrent_writes": 2})
+
+	createConcurrently(h, 4, concurrentWriteConfigs)
+
+	if max := h.server.MaxConcurrentWrites(""); max != 2 {
+		t.Fatalf("expected at most 2 writes at once, got %d", max)
+	}
+}
+
+func TestSerializedObjectTypes(t *testing.T) {
+	h := newWriteLimitedHarness(t, map[string]interface{}{"serialized_object_types": []interface{}{"group"}})
+
+	createConcurrently(h, 4, concurrentWriteConfigs)
+
+	if max := h.server.MaxConcurrentWrites("group"); max != 1 {
+		t.Fatalf("expected group writes to be made one at a time, got %d at once", max)
+	}
+	// other object types are not serialized.
+	if max := h.server.MaxConcurrentWrites("asset"); max < 2 {
+		t.Fatalf("expected asset writes to overlap, got %d at once", max)
+	}
+}
+
+func TestWriteLimitSettingsValidation(t *testing.T) {
+	for name, extra := range map[string]map[string]interface{}{
+		"negative limit":      {"max_concurrent_writes": -1},
+		"unknown object type": {"serialized_object_types": []interface{}{"widget"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := newTestHarness(t)
+			config := h.providerConfig()
+			for k, v := range extra {
+				config[k] = v
+			}
+			if diags := Provider().Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+				t.Fatal("expected the settings to be rejected")
+			}
+		})
+	}
+}
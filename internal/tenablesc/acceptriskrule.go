@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -9,9 +23,9 @@ import (
 const acceptRiskRuleEndpoint = "/acceptRiskRule"
 
 // AcceptRiskRuleBaseFields are the fields renderable directly both to and from the API.
-//  Requests take a list of repositories while all responses only ever return a single repository.
-//  HostValue response structure also varies depending on type; the client masks out this conversion
-//   to and from the API structures.
+// Requests take a list of repositories while all responses only ever return a single repository.
+// HostValue response structure also varies depending on type; the client masks out this conversion
+// to and from the API structures.
 type AcceptRiskRuleBaseFields struct {
 	ID     string    `json:"id,omitempty"`
 	Plugin *BaseInfo `json:"plugin,omitempty"`
@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -54,6 +68,16 @@ func (c *Client) GetAgentGroupsForScanner(id string) ([]*AgentGroup, error) {
 	return resp, nil
 }
 
+func (c *Client) GetAgentScan(id string) (*AgentScan, error) {
+	resp := &AgentScan{}
+
+	if _, err := c.getResource(fmt.Sprintf("%s/%s", agentScanEndpoint, id), resp); err != nil {
+		return nil, fmt.Errorf("failed to get agent scan %s: %w", id, err)
+	}
+
+	return resp, nil
+}
+
 func (c *Client) CreateAgentScan(s *AgentScan) (*AgentScan, error) {
 	resp := &AgentScan{}
 
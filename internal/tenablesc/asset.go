@@ -0,0 +1,252 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const assetsEndpoint = "/asset"
+
+// Asset defines common fields used in requests and responses for assets
+// per https://docs.tenable.com/tenablesc/api/Asset.htm
+// Some fields are presented abstracted here and internally reformatted for transport.
+type Asset struct {
+	BaseInfo
+	Type    string   `json:"type,omitempty"`
+	Prepare FakeBool `json:"prepare,omitempty"`
+	// DefinedDNSNames is a comma-separated list on the wire; this is translated during marshal/unmarshal.
+	DefinedDNSNames []string `json:"-"`
+	// DefinedIPs is a comma-separated list on the wire; this is translated during marshal/unmarshal.
+	DefinedIPs   []string            `json:"-"`
+	IPCount      ProbablyString      `json:"ipCount,omitempty"`
+	CreatedTime  UnixEpochStringTime `json:"createdTime,omitempty"`
+	ModifiedTime UnixEpochStringTime `json:"modifiedTime,omitempty"`
+	// Repositories includes a redundant `IPCount` field on the wire; this is dropped during marshal/unmarshal for consistency.
+	Repositories []Repository `json:"-"`
+	// Rules is the rule tree of dynamic assets.
+	Rules *DynamicAssetRule `json:"-"`
+	// Combinations is the expression tree of combination assets.
+	Combinations *AssetCombination `json:"-"`
+	// DefinedLDAPQuery and LDAP define ldapquery assets.
+	DefinedLDAPQuery *AssetLDAPQuery `json:"-"`
+	LDAP             *BaseInfo       `json:"-"`
+}
+
+// DynamicAssetRule is a node of a dynamic asset's rule tree.
+// Clauses match a single filter; groups combine their children with the 'all' or 'any' operator.
+// The root of the tree is a group without a type.
+type DynamicAssetRule struct {
+	Type               string                `json:"type,omitempty"`
+	Operator           string                `json:"operator,omitempty"`
+	FilterName         string                `json:"filterName,omitempty"`
+	Value              DynamicAssetRuleValue `json:"value,omitempty"`
+	PluginIDConstraint ProbablyString        `json:"pluginIDConstraint,omitempty"`
+	Children           []*DynamicAssetRule   `json:"children,omitempty"`
+}
+
+const (
+	DynamicAssetRuleTypeClause = "clause"
+	DynamicAssetRuleTypeGroup  = "group"
+)
+
+// DynamicAssetRuleValue is sent as a string, but filters referring to other objects are
+// returned as an object with an ID, and numeric filters may be returned as numbers.
+type DynamicAssetRuleValue string
+
+func (v *DynamicAssetRuleValue) UnmarshalJSON(data []byte) error {
+	var ref BaseInfo
+	if err := json.Unmarshal(data, &ref); err == nil {
+		*v = DynamicAssetRuleValue(ref.ID)
+		return nil
+	}
+
+	var s ProbablyString
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("unable to parse dynamic asset rule value %s: %w", string(data), err)
+	}
+	*v = DynamicAssetRuleValue(s)
+	return nil
+}
+
+// AssetCombination is a node of a combination asset's expression tree.
+// Leaves refer to an asset by ID; other nodes apply the 'intersection', 'union' or 'complement'
+// operator to their operands, with complement only taking Operand1.
+type AssetCombination struct {
+	ID       ProbablyString    `json:"id,omitempty"`
+	Operator string            `json:"operator,omitempty"`
+	Operand1 *AssetCombination `json:"operand1,omitempty"`
+	Operand2 *AssetCombination `json:"operand2,omitempty"`
+}
+
+const (
+	AssetCombinationIntersection = "intersection"
+	AssetCombinationUnion        = "union"
+	AssetCombinationComplement   = "complement"
+)
+
+// AssetLDAPQuery is the query run against the LDAP server of an ldapquery asset.
+type AssetLDAPQuery struct {
+	SearchBase   string `json:"searchBase,omitempty"`
+	SearchString string `json:"searchString,omitempty"`
+}
+
+type assetRequest struct {
+	Asset
+	DefinedDNSNames  string            `json:"definedDNSNames,omitempty"`
+	DefinedIPs       string            `json:"definedIPs,omitempty"`
+	Rules            *DynamicAssetRule `json:"rules,omitempty"`
+	Combinations     *AssetCombination `json:"combinations,omitempty"`
+	DefinedLDAPQuery *AssetLDAPQuery   `json:"definedLDAPQuery,omitempty"`
+	LDAP             *BaseInfo         `json:"ldap,omitempty"`
+}
+
+func assetFromExternal(a *Asset) *assetRequest {
+	return &assetRequest{
+		Asset:            *a,
+		DefinedDNSNames:  strings.Join(a.DefinedDNSNames, ","),
+		DefinedIPs:       strings.Join(a.DefinedIPs, ","),
+		Rules:            a.Rules,
+		Combinations:     a.Combinations,
+		DefinedLDAPQuery: a.DefinedLDAPQuery,
+		LDAP:             a.LDAP,
+	}
+}
+
+type assetResponse struct {
+	Asset
+	TypeFields struct {
+		DefinedDNSNames  string            `json:"definedDNSNames,omitempty"`
+		DefinedIPs       string            `json:"definedIPs,omitempty"`
+		Rules            *DynamicAssetRule `json:"rules,omitempty"`
+		Combinations     *AssetCombination `json:"combinations,omitempty"`
+		DefinedLDAPQuery *AssetLDAPQuery   `json:"definedLDAPQuery,omitempty"`
+		LDAP             *BaseInfo         `json:"ldap,omitempty"`
+	} `json:"typeFields,omitempty"`
+	Repositories []struct {
+		IPCount    string     `json:"ipCount,omitempty"`
+		Repository Repository `json:"repository,omitempty"`
+	} `json:"repositories,omitempty"`
+}
+
+type orgAssetResponse struct {
+	Manageable []*assetResponse `json:"manageable,omitempty" tenable:"recurse"`
+	Usable     []*assetResponse `json:"usable,omitempty" tenable:"recurse"`
+}
+
+func (a assetResponse) toExternal() *Asset {
+	as := &a.Asset
+
+	as.DefinedDNSNames = strings.Split(a.TypeFields.DefinedDNSNames, ",")
+	as.DefinedIPs = strings.Split(a.TypeFields.DefinedIPs, ",")
+	as.Rules = a.TypeFields.Rules
+	as.Combinations = a.TypeFields.Combinations
+	as.DefinedLDAPQuery = a.TypeFields.DefinedLDAPQuery
+	as.LDAP = a.TypeFields.LDAP
+
+	for _, r := range a.Repositories {
+		as.Repositories = append(as.Repositories, r.Repository)
+	}
+
+	return as
+}
+
+func assetSliceToExternal(ai []*assetResponse) ([]*Asset, error) {
+	assets := make([]*Asset, 0, len(ai))
+
+	for _, a := range ai {
+		asset := a.toExternal()
+		assets = append(assets, asset)
+
+	}
+	return assets, nil
+}
+
+func orgAssetSliceToExternal(o orgAssetResponse) []*Asset {
+	var orgOut []*Asset
+	orgMap := make(map[ProbablyString]bool)
+
+	for _, o := range o.Usable {
+		orgMap[o.ID] = true
+		orgOut = append(orgOut, o.toExternal())
+	}
+	for _, o := range o.Manageable {
+		if _, exists := orgMap[o.ID]; !exists {
+			orgOut = append(orgOut, o.toExternal())
+			orgMap[o.ID] = true
+		}
+	}
+
+	return orgOut
+}
+
+func (c *Client) GetAllAssets() ([]*Asset, error) {
+	// We get a different struct depending on whether we're
+	// an org or admin user, but only for get all, not individual assets.
+	var orgResponse orgAssetResponse
+	_, err := c.getResource(assetsEndpoint, &orgResponse)
+	if err == nil {
+		return orgAssetSliceToExternal(orgResponse), nil
+	}
+
+	// okay, didn't go smooth, assume we're an admin user.
+	// Using this ordering because we're far more likely to be managing assets at the org level.
+	var resp []*assetResponse
+	_, err = c.getResource(assetsEndpoint, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return assetSliceToExternal(resp)
+}
+
+func (c *Client) CreateAsset(a *Asset) (*Asset, error) {
+	resp := &assetResponse{}
+
+	if _, err := c.postResource(assetsEndpoint, assetFromExternal(a), resp); err != nil {
+		return nil, fmt.Errorf("failed to create asset: %w", err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+func (c *Client) GetAsset(id string) (*Asset, error) {
+	resp := &assetResponse{}
+
+	if _, err := c.getResource(fmt.Sprintf("%s/%s", assetsEndpoint, id), resp); err != nil {
+		return nil, fmt.Errorf("failed to get asset id %s: %w", id, err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+func (c *Client) UpdateAsset(a *Asset) (*Asset, error) {
+	resp := &assetResponse{}
+
+	if _, err := c.patchResourceWithID(assetsEndpoint, assetFromExternal(a), resp); err != nil {
+		return nil, fmt.Errorf("failed to update asset: %w", err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+func (c *Client) DeleteAsset(id string) error {
+	if _, err := c.deleteResource(fmt.Sprintf("%s/%s", assetsEndpoint, id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete asset %s: %w", id, err)
+	}
+	return nil
+}
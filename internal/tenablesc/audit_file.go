@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -48,6 +62,37 @@ func (c *Client) CreateAuditFile(a *AuditFile) (*AuditFile, error) {
 	return out.toExternal(), nil
 }
 
+// orgAuditFileResponse is how SC lists audit files to organization users.
+type orgAuditFileResponse struct {
+	Manageable []*auditFileInternal `json:"manageable" tenable:"recurse"`
+	Usable     []*auditFileInternal `json:"usable" tenable:"recurse"`
+}
+
+func (c *Client) GetAllAuditFiles() ([]*AuditFile, error) {
+	var internal []*auditFileInternal
+
+	var orgResponse orgAuditFileResponse
+	if _, err := c.getResource(auditFilesEndpoint, &orgResponse); err == nil {
+		internal = append(orgResponse.Usable, orgResponse.Manageable...)
+	} else {
+		// administrators get a plain list.
+		if _, err := c.getResource(auditFilesEndpoint, &internal); err != nil {
+			return nil, fmt.Errorf("failed to get audit files: %w", err)
+		}
+	}
+
+	var out []*AuditFile
+	seen := map[ProbablyString]bool{}
+	for _, a := range internal {
+		if !seen[a.ID] {
+			seen[a.ID] = true
+			out = append(out, a.toExternal())
+		}
+	}
+
+	return out, nil
+}
+
 func (c *Client) GetAuditFile(id string) (*AuditFile, error) {
 	res := &auditFileInternal{}
 
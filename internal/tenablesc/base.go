@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -11,7 +25,7 @@ import (
 
 // BaseInfo is used in the API to refer to related objects;
 //
-//	For input, only the ID is needed; responses will include name and description.
+// For input, only the ID is needed; responses will include name and description.
 type BaseInfo struct {
 	ID          ProbablyString `json:"id,omitempty"`
 	Name        string         `json:"name,omitempty"`
@@ -20,7 +34,7 @@ type BaseInfo struct {
 
 // UserInfo is used inb the API to refer to users.
 //
-//	For input, only the ID is needed; responses will include remaining fields.
+// For input, only the ID is needed; responses will include remaining fields.
 type UserInfo struct {
 	ID        ProbablyString `json:"id,omitempty"`
 	UUID      string         `json:"uuid,omitempty"`
@@ -51,10 +65,10 @@ func ToFakeBool(b bool) FakeBool {
 
 // ProbablyString is used for most ID fields in the API;
 //
-//	the SC API generally returns positive IDs as strings, but
-//	-1 and sometimes 0 are returned numerically.
-//	Since the API _accepts_ strings in those locations always, we only need to handle
-//	the output from calls.
+// the SC API generally returns positive IDs as strings, but
+// -1 and sometimes 0 are returned numerically.
+// Since the API _accepts_ strings in those locations always, we only need to handle
+// the output from calls.
 type ProbablyString string
 
 func (p *ProbablyString) UnmarshalJSON(data []byte) error {
@@ -78,8 +92,8 @@ func (p *ProbablyString) UnmarshalJSON(data []byte) error {
 
 // UnixEpochStringTime would ideally be time.Time, but partial-unmarshalling
 //
-//	every single place we use a timestamp to do that conversion would be somewhat excruciating.
-//	So this is the compromise.
+// every single place we use a timestamp to do that conversion would be somewhat excruciating.
+// So this is the compromise.
 type UnixEpochStringTime string
 
 func (s UnixEpochStringTime) ToDateTime() (time.Time, error) {
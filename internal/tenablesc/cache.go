@@ -0,0 +1,142 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// ListCache shares the responses of queries listing SC objects between the copies of clients made with
+// WithCachedLists. Concurrent queries for the same list are made once, and the lists of an object type
+// are dropped whenever a client sharing the cache writes to that type. It is safe to share between clients;
+// each still only gets responses to its own queries, as what SC lists depends on the credentials.
+type ListCache struct {
+	mu      sync.Mutex
+	entries map[listCacheKey]*listCacheEntry
+
+	// generations counts the writes to each object type; lists queried across a write aren't kept.
+	generations map[string]uint64
+}
+
+type listCacheKey struct {
+	// client identifies the client making the query; copies of a client share its transport.
+	client     *transport
+	objectType string
+	query      string
+}
+
+type listCacheEntry struct {
+	// done is closed once the query has been answered.
+	done     chan struct{}
+	response json.RawMessage
+	err      error
+
+	// generation is that of the object type when the query was made.
+	generation uint64
+
+	// cancelled is set when the query failed because the caller making it gave up.
+	cancelled bool
+}
+
+// NewListCache returns an empty cache.
+func NewListCache() *ListCache {
+	return &ListCache{entries: map[listCacheKey]*listCacheEntry{}, generations: map[string]uint64{}}
+}
+
+// SetListCache has the client share cache; its writes drop the cached lists of the object types written to.
+func (c *Client) SetListCache(cache *ListCache) *Client {
+	c.listCache = cache
+	return c
+}
+
+// WithCachedLists returns a copy of the client answering queries listing objects from its list cache,
+// if it has one. Use it where lists that are a little stale are acceptable, like lookups by data sources.
+func (c *Client) WithCachedLists() *Client {
+	clientWithCache := *c
+	clientWithCache.cacheLists = c.listCache != nil
+	return &clientWithCache
+}
+
+// listObjectType returns the type of objects endpoint lists, or "" if it is not a list, like /asset/12.
+func listObjectType(endpoint string) string {
+	if strings.Count(endpoint, "/") != 1 {
+		return ""
+	}
+	return endpoint
+}
+
+// writtenObjectType returns the type of objects a write to endpoint changes.
+func writtenObjectType(endpoint string) string {
+	parts := strings.SplitN(strings.TrimPrefix(endpoint, "/"), "/", 2)
+	return "/" + parts[0]
+}
+
+// get returns the response to the query, calling query only if no other caller already has, or is.
+// ctx is the context query is made with.
+func (l *ListCache) get(ctx context.Context, key listCacheKey, query func() (json.RawMessage, error)) (json.RawMessage, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	l.mu.Lock()
+	for {
+		entry, ok := l.entries[key]
+		if !ok || entry.generation != l.generations[key.objectType] {
+			break
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// A query its caller gave up on says nothing of the list; make our own unless we gave up too.
+		if !entry.cancelled || ctx.Err() != nil {
+			return entry.response, entry.err
+		}
+
+		l.mu.Lock()
+	}
+	entry := &listCacheEntry{done: make(chan struct{}), generation: l.generations[key.objectType]}
+	l.entries[key] = entry
+	l.mu.Unlock()
+
+	entry.response, entry.err = query()
+	entry.cancelled = entry.err != nil && ctx.Err() != nil
+
+	// Failures are shared with the callers already waiting, but not kept for later ones,
+	// and neither are lists the type was written to while they were queried.
+	l.mu.Lock()
+	if l.entries[key] == entry && (entry.err != nil || l.generations[key.objectType] != entry.generation) {
+		delete(l.entries, key)
+	}
+	l.mu.Unlock()
+	close(entry.done)
+
+	return entry.response, entry.err
+}
+
+// invalidate drops the cached lists of objectType, for every client: lists queried before the write
+// are of an older generation, and are queried again by the next caller.
+func (l *ListCache) invalidate(objectType string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.generations[objectType]++
+}
@@ -0,0 +1,506 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	DefaultUserAgent = "tenable.sc go client"
+)
+
+type Client struct {
+	client resty.Client
+
+	// transport is shared by copies of the client; its TLS settings, logger and traces apply to all of them.
+	transport *transport
+
+	// ctx is applied to every request made with the client, see WithContext.
+	ctx context.Context
+
+	// listCache is shared by the clients of a provider; queries listing objects are answered from it
+	// only when cacheLists is set, see WithCachedLists.
+	listCache  *ListCache
+	cacheLists bool
+
+	// token is set while a session started with Login is open.
+	token string
+
+	// retryableErrorCodes and retryableErrorMessages identify SC errors that are transient.
+	retryableErrorCodes    []int
+	retryableErrorMessages []string
+
+	// retryWrites allows retrying creates and updates, see SetRetryWrites.
+	retryWrites bool
+}
+
+type response struct {
+	response *resty.Response
+}
+
+// NewClient creates a Tenable.SC client object with defaults applied.
+// Don't forget to SetAPIKey, SetBasicAuth or Login to ensure you make credentialed queries.
+func NewClient(baseURL string) *Client {
+
+	c := resty.New().
+		SetBaseURL(baseURL).
+		SetHeader(http.CanonicalHeaderKey("User-Agent"), DefaultUserAgent).
+		SetRetryAfter(retryAfter)
+
+	t := &transport{base: c.GetClient().Transport.(*http.Transport)}
+	if u, err := url.Parse(baseURL); err == nil {
+		t.basePath = strings.TrimSuffix(u.Path, "/")
+	}
+	c.SetTransport(t)
+
+	client := &Client{
+		client:    *c,
+		transport: t,
+		// Some errors SC emits are transient issues like database locks.
+		// At the moment, we don't have a clear 'retryable' flag to work with from the
+		// vendor's opaque bitfield of an error code, so match on the message.
+		retryableErrorMessages: []string{"database is locked"},
+	}
+	client.client.AddRetryCondition(client.tenableRetryConditions)
+
+	return client
+}
+
+// WithContext returns a copy of the client whose requests are made with ctx, so cancelling ctx or
+// reaching its deadline stops requests in flight and their retries. The copy shares the connection
+// pool and credentials of c.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clientWithContext := *c
+	clientWithContext.ctx = ctx
+	return &clientWithContext
+}
+
+// SetAPIKey adds the API Key header to all queries with the client.
+func (c *Client) SetAPIKey(access, secret string) *Client {
+	c.client.SetHeader("x-apikey",
+		fmt.Sprintf("accesskey=%s; secretkey=%s;",
+			access,
+			secret))
+	return c
+}
+
+// SetBasicAuth adds the deprecated username/password auth to queries with the client.
+func (c *Client) SetBasicAuth(username, password string) *Client {
+	c.client.SetBasicAuth(username, password)
+	return c
+}
+
+// SetTLSClientConfig replaces the TLS configuration used to connect to SC, for custom CAs and client certificates.
+func (c *Client) SetTLSClientConfig(config *tls.Config) *Client {
+	c.transport.base.TLSClientConfig = config
+	return c
+}
+
+// SetRequestLogger has logger told about every attempt at a request made with the client.
+func (c *Client) SetRequestLogger(logger RequestLogger) *Client {
+	c.transport.logger = logger
+	return c
+}
+
+// SetRetries sets how many times a query failing with a transient error is retried, and the bounds of the
+// exponential backoff between attempts. A Retry-After header sent by SC takes precedence over the backoff,
+// but is still capped at maxWait.
+func (c *Client) SetRetries(count int, minWait, maxWait time.Duration) *Client {
+	c.client.
+		SetRetryCount(count).
+		SetRetryWaitTime(minWait).
+		SetRetryMaxWaitTime(maxWait)
+	return c
+}
+
+// SetRetryWrites allows retrying POST and PATCH queries. These aren't retried by default: SC may have
+// committed a create or update before failing, and sending it again can create a duplicate object.
+func (c *Client) SetRetryWrites(retryWrites bool) *Client {
+	c.retryWrites = retryWrites
+	return c
+}
+
+// SetRequestTimeout limits how long a single query attempt may take; zero means no limit.
+func (c *Client) SetRequestTimeout(timeout time.Duration) *Client {
+	c.client.SetTimeout(timeout)
+	return c
+}
+
+// AddRetryableErrors marks SC errors with any of the error codes, or an error message containing any
+// of the messages, as transient so they are retried.
+func (c *Client) AddRetryableErrors(codes []int, messages []string) *Client {
+	c.retryableErrorCodes = append(c.retryableErrorCodes, codes...)
+	c.retryableErrorMessages = append(c.retryableErrorMessages, messages...)
+	return c
+}
+
+// SetUserAgent applies a UserAgent header; if this is not supplied DefaultUserAgent is used.
+func (c *Client) SetUserAgent(agent string) *Client {
+	c.client.SetHeader(http.CanonicalHeaderKey("User-Agent"), agent)
+	return c
+}
+
+func (c *Client) tenableRetryConditions(resp *resty.Response, err error) bool {
+
+	if !c.retryWrites && resp.Request != nil &&
+		(resp.Request.Method == http.MethodPost || resp.Request.Method == http.MethodPatch) {
+		return false
+	}
+
+	// Assume internal server errors, gateway errors, and such are probably transient.
+	// Rate limiting is, by definition.
+	if resp.StatusCode() >= 500 || resp.StatusCode() == http.StatusTooManyRequests {
+		return true
+	}
+
+	// SC reports errors in the response body, not always alongside an HTTP error status.
+	scr := SCResponse{}
+	if json.Unmarshal(resp.Body(), &scr) != nil || scr.ErrorCode == 0 {
+		return false
+	}
+
+	for _, code := range c.retryableErrorCodes {
+		if scr.ErrorCode == code {
+			return true
+		}
+	}
+	for _, message := range c.retryableErrorMessages {
+		if strings.Contains(scr.ErrorMsg, message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryAfter honors the Retry-After header, in either its seconds or HTTP date form.
+// Returning zero falls back to the backoff.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	header := resp.Header().Get("Retry-After")
+	if header == "" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		// a date already past means retry right away; resty raises anything short to the minimum wait.
+		if wait := time.Until(date); wait > 0 {
+			return wait, nil
+		}
+		return time.Nanosecond, nil
+	}
+
+	return 0, nil
+}
+
+// Tenable.SC's server expects all but the default set of fields to be specified as part of queries.
+// This function inspects the provided interface for which fields should be requested.
+// All `json` field names are included in the list;
+// If the field includes `tenable:recurse` tag, then the child structure is also interrogated for
+// additional fields to extract.
+func getFieldsForStruct(d interface{}) []string {
+	t := reflect.TypeOf(d)
+
+	//if a reflect.Type is passed in directly
+	if typ, ok := d.(reflect.Type); ok {
+		t = typ
+	}
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fMap := map[string]interface{}{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if f := field.Tag.Get("tenable"); strings.Contains(f, "recurse") {
+			for _, k := range getFieldsForStruct(field.Type) {
+				fMap[k] = nil
+			}
+		} else if f := field.Tag.Get("json"); f != "" {
+			fMap[strings.Split(f, ",")[0]] = nil
+		} else if field.Anonymous {
+			for _, k := range getFieldsForStruct(field.Type) {
+				fMap[k] = nil
+			}
+		}
+	}
+
+	fields := make([]string, 0, len(fMap))
+
+	for k := range fMap {
+		fields = append(fields, k)
+	}
+
+	sort.Stable(sort.StringSlice(fields))
+
+	return fields
+}
+
+// Generalized handlers for all endpoint queries.
+
+// newRequest starts a request bound to the client's context, if it has one.
+func (c *Client) newRequest() *resty.Request {
+	req := c.client.NewRequest()
+	if c.ctx != nil {
+		req.SetContext(c.ctx)
+	}
+	return req
+}
+
+func (c *Client) getResource(endpoint string, dest interface{}) (*response, error) {
+	if !isPTR(dest) {
+		return nil, errors.New("provide a pointer to the data source")
+	}
+
+	req := c.newRequest()
+
+	f := getFieldsForStruct(dest)
+	if len(f) > 0 {
+		req.SetQueryParam("fields",
+			strings.Join(f, ","))
+	}
+
+	if objectType := listObjectType(endpoint); c.cacheLists && objectType != "" {
+		key := listCacheKey{client: c.transport, objectType: objectType, query: strings.Join(f, ",")}
+		list, err := c.listCache.get(c.ctx, key, func() (json.RawMessage, error) {
+			var list json.RawMessage
+			_, err := c.handleRequest(resty.MethodGet, endpoint, req, &list)
+			return list, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		// every caller gets its own copy of the objects.
+		if err := json.Unmarshal(list, dest); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return nil, nil
+	}
+
+	return c.handleRequest(resty.MethodGet, endpoint, req, dest)
+}
+
+func (c *Client) postResource(endpoint string, input interface{}, dest interface{}) (*response, error) {
+	if !isPTR(dest) {
+		return nil, errors.New("provide a pointer to the data source")
+	}
+
+	req := c.newRequest().SetBody(input)
+
+	return c.handleRequest(resty.MethodPost, endpoint, req, dest)
+}
+
+func (c *Client) patchResource(endpoint string, input interface{}, dest interface{}) (*response, error) {
+	if !isPTR(dest) {
+		return nil, errors.New("provide a pointer to the data source")
+	}
+
+	req := c.newRequest().SetBody(input)
+
+	return c.handleRequest(resty.MethodPatch, endpoint, req, dest)
+}
+
+func (c *Client) patchResourceWithID(endpoint string, input interface{}, dest interface{}) (*response, error) {
+
+	id, err := idFromStruct(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ID from struct: %w", err)
+	}
+	endpoint = fmt.Sprintf("%s/%s", endpoint, id)
+
+	return c.patchResource(endpoint, input, dest)
+}
+
+func (c *Client) deleteResource(endpoint string, input interface{}, dest interface{}) (*response, error) {
+	if !isPTR(dest) {
+		return nil, errors.New("provide a pointer to the data source")
+	}
+
+	req := c.newRequest().SetBody(input)
+
+	return c.handleRequest(resty.MethodDelete, endpoint, req, dest)
+}
+
+// handleRequest implements the application-side retry and backoff logic for all queries, retrying in case of
+// application-side errors that are clearly transient.
+func (c *Client) handleRequest(method, endpoint string, request *resty.Request, dest interface{}) (*response, error) {
+	var err error
+
+	if request == nil {
+		request = c.newRequest()
+	}
+
+	req := request.
+		// Whether a good response or an error, give us the SCResponse parse.
+		SetResult(SCResponse{}).
+		SetError(SCResponse{})
+
+	resp, err := req.Execute(method, endpoint)
+
+	// even a failed write may have changed something.
+	if method != resty.MethodGet && c.listCache != nil {
+		c.listCache.invalidate(writtenObjectType(endpoint))
+	}
+
+	if err != nil {
+		return &response{resp}, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	return &response{resp}, handleResponse(resp, dest)
+
+}
+
+type SCResponse struct {
+	Response  json.RawMessage `json:"response"`
+	ErrorCode int             `json:"error_code"`
+	ErrorMsg  string          `json:"error_msg"`
+	Timestamp int             `json:"timestamp"`
+	Warnings  []string        `json:"warning"`
+}
+
+// notFoundErrorCodes are the error codes SC answers requests for objects that don't exist with.
+var notFoundErrorCodes = map[int]bool{
+	146: true,
+}
+
+// IsNotFound reports whether the response is SC confirming the requested object does not exist,
+// as opposed to any other error, like the caller not being allowed to see it.
+func (s SCResponse) IsNotFound() bool {
+	if notFoundErrorCodes[s.ErrorCode] {
+		return true
+	}
+	// codes are not consistent across SC versions and endpoints, but the messages are.
+	return s.ErrorCode != 0 && strings.Contains(strings.ToLower(s.ErrorMsg), "not found")
+}
+
+func handleHTTPError(resp *resty.Response, scr *SCResponse) error {
+	var respErr error
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+
+		httpErr := HTTPError{
+			baseError: baseError{
+				message: "unexpected response from server",
+			},
+			ResponseCode: resp.StatusCode(),
+			Body:         string(resp.Body()),
+		}
+
+		// SC's version of not found for some reason; but authorization failures are 403s as well,
+		// and only the error code in the body tells the two apart.
+		if resp.StatusCode() == 403 {
+			if scr != nil && scr.IsNotFound() {
+				e := NotFoundError(httpErr)
+				e.baseError.parent = httpErr
+				respErr = e
+			} else {
+				e := PermissionError(httpErr)
+				e.baseError.parent = httpErr
+				respErr = e
+			}
+		} else {
+			respErr = httpErr
+		}
+		return respErr
+	}
+
+	return nil
+}
+
+// handleResponse's job is to handle finishing the unmarshal, as well as
+// wrapping the error if there's an error here.
+func handleResponse(resp *resty.Response, dest interface{}) error {
+	//try to unmarshal the response anyways incase there's something interesting
+	scr := &SCResponse{}
+	if err := json.Unmarshal(resp.Body(), scr); err != nil {
+		if respErr := handleHTTPError(resp, nil); respErr != nil {
+			return respErr
+		}
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	respErr := handleHTTPError(resp, scr)
+
+	if scr.ErrorCode != 0 {
+		return SCError{
+			baseError: baseError{
+				message: scr.ErrorMsg,
+				parent:  respErr,
+			},
+			SCErrorCode: scr.ErrorCode,
+		}
+	}
+	if respErr != nil {
+		return respErr
+	}
+	if dest != nil {
+		if err := json.Unmarshal(scr.Response, dest); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func isPTR(d interface{}) bool {
+	t := reflect.TypeOf(d)
+
+	return d == nil || t.Kind() == reflect.Ptr
+}
+
+func idFromStruct(d interface{}) (string, error) {
+	v := reflect.ValueOf(d)
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return "", errors.New("method only functions on structs or struct pointers")
+	}
+
+	id := v.FieldByName("ID")
+
+	if !id.IsValid() {
+		return "", errors.New("no ID field was found")
+	} else if id.Kind() != reflect.String {
+		return "", errors.New("id field is not a string")
+	} else if id.IsZero() {
+		return "", errors.New("id field is empty")
+	}
+
+	return id.String(), nil
+}
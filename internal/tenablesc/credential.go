@@ -1,13 +1,28 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
 const credentialEndpoint = "/credential"
 
 // Credential is massively pared back from the possible types in https://docs.tenable.com/tenablesc/api/Credential.htm
-// This is not wired to directly manage credentials, only to find and delete them.
+// It is used to find and delete credentials; see CredentialDetails for managing them.
 type Credential struct {
 	BaseInfo
 	Type      string   `json:"type"`
@@ -56,6 +71,143 @@ func (c *Client) GetCredential(id string) (*Credential, error) {
 	return resp, nil
 }
 
+// CredentialTypeFields holds the fields specific to each credential type and auth type.
+// Requests take them at the top level, while responses nest them under typeFields.
+// Secrets (passwords, keys, passphrases and community strings) are write-only; SC never returns them.
+type CredentialTypeFields struct {
+	AuthType string `json:"authType,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Domain is only used for windows credentials.
+	Domain string `json:"domain,omitempty"`
+
+	// SSH fields. PrivateKey and PublicKey are uploaded file names in requests.
+	PrivateKey          string `json:"privateKey,omitempty"`
+	PublicKey           string `json:"publicKey,omitempty"`
+	Passphrase          string `json:"passphrase,omitempty"`
+	PrivilegeEscalation string `json:"privilegeEscalation,omitempty"`
+	EscalationUsername  string `json:"escalationUsername,omitempty"`
+	EscalationPassword  string `json:"escalationPassword,omitempty"`
+	EscalationPath      string `json:"escalationPath,omitempty"`
+	EscalationSuUser    string `json:"escalationSuUser,omitempty"`
+
+	// Database fields.
+	Login             string `json:"login,omitempty"`
+	DBType            string `json:"dbType,omitempty"`
+	SID               string `json:"sid,omitempty"`
+	Port              string `json:"port,omitempty"`
+	OracleAuthType    string `json:"oracleAuthType,omitempty"`
+	SQLServerAuthType string `json:"sqlServerAuthType,omitempty"`
+
+	// SNMP fields.
+	CommunityString string `json:"communityString,omitempty"`
+}
+
+// CredentialDetails is a single credential including its type-specific fields, as used to create, update,
+// and read back individual credentials.
+type CredentialDetails struct {
+	Credential
+	CredentialTypeFields
+	// ClearedSecrets are the JSON names of secrets to send empty, which SC takes as removing them.
+	// Other empty secrets are left out of requests, leaving them unchanged.
+	ClearedSecrets []string `json:"-"`
+}
+
+func (c CredentialDetails) MarshalJSON() ([]byte, error) {
+	type plain CredentialDetails
+	body, err := json.Marshal(plain(c))
+	if err != nil || len(c.ClearedSecrets) == 0 {
+		return body, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	for _, k := range c.ClearedSecrets {
+		fields[k] = json.RawMessage(`""`)
+	}
+	return json.Marshal(fields)
+}
+
+// credentialInternal is the response format of a single credential.
+type credentialInternal struct {
+	Credential
+	TypeFields CredentialTypeFields `json:"typeFields"`
+}
+
+func (c credentialInternal) toExternal() *CredentialDetails {
+	return &CredentialDetails{
+		Credential:           c.Credential,
+		CredentialTypeFields: c.TypeFields,
+	}
+}
+
+// uploadCredentialKeys replaces SSH key contents in the input with the names of the uploaded files
+// SC expects them to be referenced by.
+func (c *Client) uploadCredentialKeys(cred *CredentialDetails) error {
+	var err error
+
+	if cred.PrivateKey != "" {
+		cred.PrivateKey, err = c.handleSSHCertCredFileUpload(cred.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("unable to upload private key for credential name %s: %w", cred.Name, err)
+		}
+	}
+
+	if cred.PublicKey != "" {
+		cred.PublicKey, err = c.handleSSHCertCredFileUpload(cred.PublicKey)
+		if err != nil {
+			return fmt.Errorf("unable to upload public key for credential name %s: %w", cred.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateCredential creates a credential of any type. SSH private and public keys are given as file contents
+// and uploaded before the credential is created.
+func (c *Client) CreateCredential(cred *CredentialDetails) (*CredentialDetails, error) {
+	input := *cred
+	if err := c.uploadCredentialKeys(&input); err != nil {
+		return nil, err
+	}
+
+	resp := &credentialInternal{}
+	if _, err := c.postResource(credentialEndpoint, input, resp); err != nil {
+		return nil, fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+// GetCredentialDetails returns a credential including its non-secret type-specific fields.
+func (c *Client) GetCredentialDetails(id string) (*CredentialDetails, error) {
+	resp := &credentialInternal{}
+
+	if _, err := c.getResource(fmt.Sprintf("%s/%s", credentialEndpoint, id), resp); err != nil {
+		return nil, fmt.Errorf("failed to get credential id %s: %w", id, err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+// UpdateCredential updates a credential; secrets left empty are left unchanged by SC.
+// SSH private and public keys are given as file contents and uploaded before the credential is updated.
+func (c *Client) UpdateCredential(cred *CredentialDetails) (*CredentialDetails, error) {
+	input := *cred
+	if err := c.uploadCredentialKeys(&input); err != nil {
+		return nil, err
+	}
+
+	resp := &credentialInternal{}
+	if _, err := c.patchResourceWithID(credentialEndpoint, input, resp); err != nil {
+		return nil, fmt.Errorf("failed to update credential: %w", err)
+	}
+
+	return resp.toExternal(), nil
+}
+
 func (c *Client) DeleteCredential(id string) error {
 	if _, err := c.deleteResource(fmt.Sprintf("%s/%s", credentialEndpoint, id), nil, nil); err != nil {
 		return fmt.Errorf("unable to delete credential with id %s: %w", id, err)
@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -7,7 +21,7 @@ import (
 const currentUserEndpoint = "/currentUser"
 
 // CurrentUser represents response structure for https://docs.tenable.com/tenablesc/api/Current-User.htm
-//   This is perfect for testing credential validity and user type.
+// This is perfect for testing credential validity and user type.
 type CurrentUser struct {
 	BaseInfo
 	Status       string   `json:"status,omitempty"`
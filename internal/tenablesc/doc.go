@@ -0,0 +1,22 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenablesc is a client for the Tenable.SC REST API.
+//
+// It is a fork of github.com/palantir/tenablesc-client v0.10.0, whose LICENSE is kept alongside it.
+// The vendored module could not be extended in place: new endpoints such as credential create and update need
+// its unexported request helpers, Go doesn't allow adding methods to its Client from this module, and edits under
+// vendor/ are discarded by the next `go mod vendor`. Carrying it in-tree lets endpoints and transport options be
+// added alongside the provider resources that need them; changes worth having upstream should be sent there too.
+package tenablesc
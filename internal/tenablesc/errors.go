@@ -0,0 +1,58 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"fmt"
+)
+
+type baseError struct {
+	message string
+	parent  error
+}
+
+func (b baseError) Error() string {
+	return b.message
+}
+
+func (b baseError) Unwrap() error {
+	return b.parent
+}
+
+type HTTPError struct {
+	baseError
+	ResponseCode int
+	Body         string
+}
+
+func (h HTTPError) Error() string {
+	return fmt.Sprintf("%s, response code '%d' body:'%s'", h.message, h.ResponseCode, h.Body)
+}
+
+// NotFoundError is returned when SC confirms the requested object does not exist.
+type NotFoundError HTTPError
+
+// PermissionError is returned for any other 403, such as credentials that are invalid, expired,
+// or lack the role or organization to access the object.
+type PermissionError HTTPError
+
+type SCError struct {
+	baseError
+	SCErrorCode int
+}
+
+func (s SCError) Error() string {
+	return fmt.Sprintf("%s, error code %d", s.message, s.SCErrorCode)
+}
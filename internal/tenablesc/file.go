@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -64,7 +78,7 @@ func (c *Client) UploadFileFromReader(reader io.Reader, path, context string) (*
 
 	f := &File{}
 
-	req := c.client.NewRequest().
+	req := c.newRequest().
 		SetBody(bodyBuffer).
 		SetHeader("Content-Type", writer.FormDataContentType()).
 		SetQueryParam("context", context)
@@ -83,7 +97,7 @@ func (c *Client) DeleteFile(filename string) error {
 		Filename: filename,
 	}
 	resp := &SCResponse{}
-	req := c.client.NewRequest().SetBody(f).SetResult(resp).SetError(resp)
+	req := c.newRequest().SetBody(f).SetResult(resp).SetError(resp)
 	r, err := req.Execute(resty.MethodPost, fmt.Sprintf("%s/%s", filesEndpoint, "clear"))
 
 	if err != nil {
@@ -0,0 +1,80 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"fmt"
+)
+
+const groupEndpoint = "/group"
+
+// Group represents request/response structure for https://docs.tenable.com/tenablesc/api/Group.htm
+type Group struct {
+	BaseInfo
+	// DefiningAssets and Repositories are always sent so that they can be cleared.
+	DefiningAssets []BaseInfo `json:"definingAssets"`
+	Repositories   []BaseInfo `json:"repositories"`
+	CreateUser     FakeBool   `json:"createUser,omitempty"`
+	Assets         FakeBool   `json:"assets,omitempty"`
+	Policies       FakeBool   `json:"policies,omitempty"`
+}
+
+func (c *Client) GetAllGroups() ([]*Group, error) {
+	var resp []*Group
+
+	if _, err := c.getResource(groupEndpoint, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetGroup(id string) (*Group, error) {
+	resp := &Group{}
+
+	if _, err := c.getResource(fmt.Sprintf("%s/%s", groupEndpoint, id), resp); err != nil {
+		return nil, fmt.Errorf("failed to get group id %s: %w", id, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) CreateGroup(g *Group) (*Group, error) {
+	resp := &Group{}
+
+	if _, err := c.postResource(groupEndpoint, g, resp); err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) UpdateGroup(g *Group) (*Group, error) {
+	resp := &Group{}
+
+	if _, err := c.patchResourceWithID(groupEndpoint, g, resp); err != nil {
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) DeleteGroup(id string) error {
+	if _, err := c.deleteResource(fmt.Sprintf("%s/%s", groupEndpoint, id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete group %s: %w", id, err)
+	}
+
+	return nil
+}
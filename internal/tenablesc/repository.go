@@ -1,8 +1,21 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 )
@@ -15,8 +28,22 @@ type Repository struct {
 	RepoBaseFields
 	RepoFieldsCommon
 	RepoIPFields
+	RepoRemoteFields
 }
 
+// Repository data formats and types with modeled typeFields. Others, like mobile repositories, are
+// returned with only their base fields.
+const (
+	RepoDataFormatIPv4      = "IPv4"
+	RepoDataFormatIPv6      = "IPv6"
+	RepoDataFormatAgent     = "agent"
+	RepoDataFormatUniversal = "universal"
+
+	RepoTypeLocal   = "Local"
+	RepoTypeRemote  = "Remote"
+	RepoTypeOffline = "Offline"
+)
+
 // RepoFieldsCommon includes the fields common to requests and responses in this endpoint for all repository types.
 type RepoFieldsCommon struct {
 	ActiveVulnsLifetime     string   `json:"activeVulnsLifetime,omitempty"`
@@ -36,6 +63,12 @@ type RepoIPFields struct {
 	NessusSchedule         *NessusSchedule     `json:"nessusSchedule,omitempty"`
 }
 
+// RepoRemoteFields includes the fields only available in Remote repositories, which are synced from another SC.
+type RepoRemoteFields struct {
+	RemoteID string `json:"remoteID,omitempty"`
+	RemoteIP string `json:"remoteIP,omitempty"`
+}
+
 // RepoBaseFields includes the Repository fields common to responses from this endpoint and others.
 type RepoBaseFields struct {
 	BaseInfo
@@ -59,23 +92,20 @@ type RepoOrganization struct {
 	GroupAssign string `json:"groupAssign,omitempty"`
 }
 
-//input and output formats are different.  Handle the differences internally
+// input and output formats are different.  Handle the differences internally
 type repoInternal struct {
 	RepoBaseFields
 	TypeFields json.RawMessage `json:"typeFields,omitempty"`
 }
 
 func (r repoInternal) toExternal() (*Repository, error) {
-	if r.Type != "Local" {
-		return nil, fmt.Errorf("Repo type %s is not supported.  Only local is", r.Type)
-	}
-
 	repo := &Repository{
 		RepoBaseFields: r.RepoBaseFields,
 	}
 
-	if r.DataFormat == "mobile" {
-		return nil, errors.New("mobile repo data format is not supported")
+	// Repositories we don't model are returned as-is, so that listing them doesn't fail.
+	if !repo.IsModeled() || len(r.TypeFields) == 0 {
+		return repo, nil
 	}
 
 	if err := json.Unmarshal(r.TypeFields, &repo.RepoFieldsCommon); err != nil {
@@ -88,9 +118,30 @@ func (r repoInternal) toExternal() (*Repository, error) {
 		}
 	}
 
+	if r.Type == RepoTypeRemote {
+		if err := json.Unmarshal(r.TypeFields, &repo.RepoRemoteFields); err != nil {
+			return nil, fmt.Errorf("faild to unmarshal typeFields: %w", err)
+		}
+	}
+
 	return repo, nil
 }
 
+// IsModeled reports whether the repository's type and data format are ones this client fully models.
+func (r *Repository) IsModeled() bool {
+	switch r.DataFormat {
+	case RepoDataFormatIPv4, RepoDataFormatIPv6, RepoDataFormatAgent, RepoDataFormatUniversal:
+	default:
+		return false
+	}
+
+	switch r.Type {
+	case RepoTypeLocal, RepoTypeRemote, RepoTypeOffline:
+		return true
+	}
+	return false
+}
+
 func repoSliceToExternal(r []repoInternal) ([]*Repository, error) {
 	repos := make([]*Repository, 0, len(r))
 
@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -139,7 +153,7 @@ func (c *Client) DownloadScanResult(id string) ([]byte, error) {
 }
 
 func (c *Client) internalDownloadScanResult(id string) ([]byte, error) {
-	req := c.client.NewRequest()
+	req := c.newRequest()
 	req.SetBody(struct {
 		DownloadType string `json:"downloadType"`
 	}{DownloadType: "v2"},
@@ -152,8 +166,9 @@ func (c *Client) internalDownloadScanResult(id string) ([]byte, error) {
 		return nil, err
 	}
 
-	if respErr := handleHTTPError(resp); respErr != nil {
-		return nil, respErr
+	if resp.IsError() {
+		// errors still come in the usual envelope, rather than the file.
+		return nil, handleResponse(resp, nil)
 	}
 
 	return resp.Body(), nil
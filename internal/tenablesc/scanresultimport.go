@@ -1,3 +1,17 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
 package tenablesc
 
 import (
@@ -27,7 +41,7 @@ func (c *Client) ImportScanResult(resultImport *ScanResultImport) error {
 }
 
 // ImportScanResultFile composes the UploadFile and ImportScanResult calls necessary to import a scan result to a target repository.
-//   Path is expected to be a path on the local filesystem.
+// Path is expected to be a path on the local filesystem.
 func (c *Client) ImportScanResultFile(path string, repositoryID string, classifyMitigatedAge string, dhcpTracking, scanningVirtualHosts bool) error {
 
 	file, err := c.UploadFile(path, "")
@@ -0,0 +1,67 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const tokenEndpoint = "/token"
+
+// sessionTokenHeader carries the token of a username/password session; SC also requires the session cookie it set at login.
+const sessionTokenHeader = "X-SecurityCenter"
+
+// Token represents response structure from https://docs.tenable.com/tenablesc/api/Token.htm
+type Token struct {
+	Token            ProbablyString `json:"token"`
+	UnassociatedCert string         `json:"unassociatedCert,omitempty"`
+}
+
+type tokenRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Login starts a username/password session. All further queries with the client use the session,
+// so call Logout once done with the client to release it.
+func (c *Client) Login(username, password string) error {
+	resp := &Token{}
+
+	if _, err := c.postResource(tokenEndpoint, tokenRequest{Username: username, Password: password}, resp); err != nil {
+		return fmt.Errorf("failed to log in as %s: %w", username, err)
+	}
+
+	c.token = string(resp.Token)
+	c.client.SetHeader(http.CanonicalHeaderKey(sessionTokenHeader), c.token)
+
+	return nil
+}
+
+// Logout releases the session started by Login. It does nothing if there is no session.
+func (c *Client) Logout() error {
+	if c.token == "" {
+		return nil
+	}
+
+	if _, err := c.deleteResource(tokenEndpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to log out: %w", err)
+	}
+
+	c.token = ""
+	c.client.Header.Del(sessionTokenHeader)
+
+	return nil
+}
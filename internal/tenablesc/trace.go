@@ -0,0 +1,278 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceEntry is a request made to SC and the response to it, as recorded by RecordTrace.
+// Credentials and other secrets are masked before an entry is written.
+type TraceEntry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// Endpoint is relative to the base URL of the client, so a trace replays against any.
+	Endpoint string `json:"endpoint"`
+	Query    string `json:"query,omitempty"`
+
+	RequestHeaders http.Header `json:"request_headers,omitempty"`
+	RequestBody    string      `json:"request_body,omitempty"`
+
+	StatusCode      int         `json:"status_code,omitempty"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    string      `json:"response_body,omitempty"`
+
+	// Error is set instead of the response when SC could not be reached.
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// traceMask replaces secrets in traces.
+const traceMask = "***"
+
+// sensitiveTraceHeaders carry credentials, and are masked in traces.
+var sensitiveTraceHeaders = []string{"x-apikey", "X-SecurityCenter", "Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveTraceFields are masked in JSON bodies wherever a key contains one of them, ignoring case;
+// this covers SC's credential fields like privilegeEscalationPassword and communityString as well.
+var sensitiveTraceFields = []string{"password", "passphrase", "privatekey", "secret", "token", "accesskey", "community"}
+
+// TraceRecorder writes requests made to SC, and the responses to them, as JSON lines of TraceEntry.
+// It is safe to share between clients.
+type TraceRecorder struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewTraceRecorder returns a recorder writing to w.
+func NewTraceRecorder(w io.Writer) *TraceRecorder {
+	return &TraceRecorder{w: w}
+}
+
+// RecordTrace has every request made with the client, and the response to it, recorded by recorder.
+// The trace can be served back to a client with ReplayTrace.
+func (c *Client) RecordTrace(recorder *TraceRecorder) *Client {
+	c.transport.recorder = recorder
+	return c
+}
+
+// TraceReplayer answers requests from a trace written by a TraceRecorder instead of SC.
+// Each request is answered with the next recorded response to the same method, endpoint and query;
+// requests the trace has no response left for fail. It is safe to share between clients, which
+// replays a trace recorded by several clients in the order they made their requests.
+type TraceReplayer struct {
+	mu        sync.Mutex
+	responses map[string][]TraceEntry
+}
+
+// ReplayTrace has the client answer its requests from replayer instead of SC.
+func (c *Client) ReplayTrace(replayer *TraceReplayer) *Client {
+	c.transport.replayer = replayer
+	return c
+}
+
+// record makes the request to endpoint with roundTrip, and writes it and its response to the trace.
+func (t *TraceRecorder) record(req *http.Request, endpoint string, roundTrip func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	entry := TraceEntry{
+		Time:           time.Now().UTC(),
+		Method:         req.Method,
+		Endpoint:       endpoint,
+		Query:          req.URL.RawQuery,
+		RequestHeaders: sanitizeTraceHeaders(req.Header),
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		entry.RequestBody = sanitizeTraceBody(req.Header.Get("Content-Type"), body)
+	}
+
+	resp, err := roundTrip(req)
+	entry.DurationMS = time.Since(entry.Time).Milliseconds()
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		entry.StatusCode = resp.StatusCode
+		entry.ResponseHeaders = sanitizeTraceHeaders(resp.Header)
+		entry.ResponseBody = sanitizeTraceBody(resp.Header.Get("Content-Type"), body)
+	}
+
+	t.write(entry)
+
+	return resp, err
+}
+
+// write appends entry to the trace; a trace that can't be written must not fail the request.
+func (t *TraceRecorder) write(entry TraceEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(append(line, '\n'))
+}
+
+// NewTraceReplayer reads the trace to replay from r.
+func NewTraceReplayer(r io.Reader) (*TraceReplayer, error) {
+	t := &TraceReplayer{responses: map[string][]TraceEntry{}}
+
+	scanner := bufio.NewScanner(r)
+	// responses listing every object of a type can be large.
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		entry := TraceEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse trace line %d: %w", line, err)
+		}
+		key := traceKey(entry.Method, entry.Endpoint, entry.Query)
+		t.responses[key] = append(t.responses[key], entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace: %w", err)
+	}
+
+	return t, nil
+}
+
+// replay answers the request to endpoint with the next response recorded for it.
+func (t *TraceReplayer) replay(req *http.Request, endpoint string) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := traceKey(req.Method, endpoint, req.URL.RawQuery)
+
+	t.mu.Lock()
+	entries := t.responses[key]
+	if len(entries) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("trace has no response left for %s", key)
+	}
+	entry := entries[0]
+	t.responses[key] = entries[1:]
+	t.mu.Unlock()
+
+	if entry.Error != "" {
+		return nil, fmt.Errorf("recorded error: %s", entry.Error)
+	}
+
+	header := entry.ResponseHeaders.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.StatusCode, http.StatusText(entry.StatusCode)),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(entry.ResponseBody)),
+		ContentLength: int64(len(entry.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+func traceKey(method, endpoint, query string) string {
+	if query == "" {
+		return method + " " + endpoint
+	}
+	return method + " " + endpoint + "?" + query
+}
+
+func sanitizeTraceHeaders(header http.Header) http.Header {
+	sanitized := header.Clone()
+	for _, name := range sensitiveTraceHeaders {
+		if sanitized.Get(name) != "" {
+			sanitized.Set(name, traceMask)
+		}
+	}
+	return sanitized
+}
+
+// sanitizeTraceBody masks the secrets of JSON bodies. Other bodies, like the contents of uploaded files,
+// are left out of traces entirely.
+func sanitizeTraceBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	if !strings.Contains(contentType, "json") || json.Unmarshal(body, &decoded) != nil {
+		return fmt.Sprintf("<%d bytes of %s omitted>", len(body), contentType)
+	}
+
+	sanitized, err := json.Marshal(maskTraceFields(decoded))
+	if err != nil {
+		return ""
+	}
+	return string(sanitized)
+}
+
+func maskTraceFields(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveTraceField(key) {
+				if s, ok := field.(string); !ok || s != "" {
+					v[key] = traceMask
+				}
+				continue
+			}
+			v[key] = maskTraceFields(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = maskTraceFields(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveTraceField(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range sensitiveTraceFields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
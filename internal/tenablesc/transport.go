@@ -0,0 +1,70 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestLogger is told about every attempt at a request to SC: the endpoint, relative to the base URL of
+// the client, the HTTP status SC answered with and how long it took to. err is set when SC could not be reached.
+type RequestLogger func(ctx context.Context, method, endpoint string, statusCode int, duration time.Duration, err error)
+
+// transport is the round tripper of a client, reporting each attempt at a request to its logger.
+// Retries happen above it, so every attempt is reported on its own.
+type transport struct {
+	base *http.Transport
+
+	// recorder records requests made, and replayer answers them instead of base; see RecordTrace and ReplayTrace.
+	recorder *TraceRecorder
+	replayer *TraceReplayer
+
+	// basePath is the path of the base URL, stripped from the endpoints reported and traced.
+	basePath string
+	logger   RequestLogger
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	endpoint := strings.TrimPrefix(req.URL.Path, t.basePath)
+
+	roundTrip := t.base.RoundTrip
+	if t.replayer != nil {
+		roundTrip = func(req *http.Request) (*http.Response, error) {
+			return t.replayer.replay(req, endpoint)
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	if t.recorder != nil {
+		resp, err = t.recorder.record(req, endpoint, roundTrip)
+	} else {
+		resp, err = roundTrip(req)
+	}
+
+	if t.logger != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		t.logger(req.Context(), req.Method, endpoint, statusCode, time.Since(start), err)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenablesc
+
+import (
+	"fmt"
+)
+
+const userEndpoint = "/user"
+
+// UserBaseFields are the user fields rendered directly both to and from the API.
+// Contact details are always sent so that they can be cleared.
+type UserBaseFields struct {
+	ID           ProbablyString `json:"id,omitempty"`
+	Username     string         `json:"username,omitempty"`
+	AuthType     string         `json:"authType,omitempty"`
+	LDAPUsername string         `json:"ldapUsername,omitempty"`
+	Locked       FakeBool       `json:"locked,omitempty"`
+	Firstname    string         `json:"firstname"`
+	Lastname     string         `json:"lastname"`
+	Title        string         `json:"title"`
+	Email        string         `json:"email"`
+	Phone        string         `json:"phone"`
+	Address      string         `json:"address"`
+	City         string         `json:"city"`
+	State        string         `json:"state"`
+	Country      string         `json:"country"`
+}
+
+// User represents the fields for https://docs.tenable.com/tenablesc/api/User.htm
+// Requests reference related objects by ID fields, while responses embed the objects;
+// the client masks out this conversion.
+type User struct {
+	UserBaseFields
+	// Password is write-only; SC never returns it.
+	Password           string `json:"password,omitempty"`
+	RoleID             string `json:"roleID,omitempty"`
+	GroupID            string `json:"groupID,omitempty"`
+	OrgID              string `json:"orgID,omitempty"`
+	ResponsibleAssetID string `json:"responsibleAssetID,omitempty"`
+	LDAPID             string `json:"ldapID,omitempty"`
+}
+
+type userInternal struct {
+	UserBaseFields
+	Role             *BaseInfo `json:"role,omitempty"`
+	Group            *BaseInfo `json:"group,omitempty"`
+	Organization     *BaseInfo `json:"organization,omitempty"`
+	ResponsibleAsset *BaseInfo `json:"responsibleAsset,omitempty"`
+	LDAP             *BaseInfo `json:"ldap,omitempty"`
+}
+
+// idOf returns the ID of a related object, treating the -1 SC uses for 'none' as empty.
+func idOf(b *BaseInfo) string {
+	if b == nil || b.ID == "-1" {
+		return ""
+	}
+	return string(b.ID)
+}
+
+func (u userInternal) toExternal() *User {
+	return &User{
+		UserBaseFields:     u.UserBaseFields,
+		RoleID:             idOf(u.Role),
+		GroupID:            idOf(u.Group),
+		OrgID:              idOf(u.Organization),
+		ResponsibleAssetID: idOf(u.ResponsibleAsset),
+		LDAPID:             idOf(u.LDAP),
+	}
+}
+
+func (c *Client) GetAllUsers() ([]*User, error) {
+	var resp []userInternal
+
+	if _, err := c.getResource(userEndpoint, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	users := make([]*User, 0, len(resp))
+	for _, u := range resp {
+		users = append(users, u.toExternal())
+	}
+
+	return users, nil
+}
+
+func (c *Client) GetUser(id string) (*User, error) {
+	resp := &userInternal{}
+
+	if _, err := c.getResource(fmt.Sprintf("%s/%s", userEndpoint, id), resp); err != nil {
+		return nil, fmt.Errorf("failed to get user id %s: %w", id, err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+func (c *Client) CreateUser(u *User) (*User, error) {
+	resp := &userInternal{}
+
+	if _, err := c.postResource(userEndpoint, u, resp); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+func (c *Client) UpdateUser(u *User) (*User, error) {
+	resp := &userInternal{}
+
+	if _, err := c.patchResourceWithID(userEndpoint, u, resp); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return resp.toExternal(), nil
+}
+
+func (c *Client) DeleteUser(id string) error {
+	if _, err := c.deleteResource(fmt.Sprintf("%s/%s", userEndpoint, id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete user %s: %w", id, err)
+	}
+
+	return nil
+}
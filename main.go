@@ -15,6 +15,14 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/palantir/terraform-provider-tenablesc/internal/provider"
 )
@@ -26,7 +34,65 @@ import (
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-name terraform-provider-tenablesc
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate(os.Args[2:]))
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: provider.Provider,
 	})
+
+	// Serve returns once Terraform is done with the plugin.
+	provider.CloseSessions()
+}
+
+// generate writes configuration for the objects of an SC instance, for adopting them with `terraform plan`.
+func generate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate [-out FILE] [-types TYPE,...]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes Terraform configuration and import blocks for the objects of the SC instance")
+		fmt.Fprintln(flags.Output(), "at TENABLESC_URI that the credentials in TENABLESC_ACCESS_KEY and TENABLESC_SECRET_KEY,")
+		fmt.Fprintln(flags.Output(), "or TENABLESC_USERNAME and TENABLESC_PASSWORD, can manage.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	out := flags.String("out", "", "file to write the configuration to, instead of standard output")
+	types := flags.String("types", "", "comma-separated resource types to generate configuration for, instead of all of them")
+	_ = flags.Parse(args)
+
+	var resourceTypes []string
+	if *types != "" {
+		resourceTypes = strings.Split(*types, ",")
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	diags := provider.Generate(context.Background(), w, resourceTypes)
+	provider.CloseSessions()
+
+	for _, d := range diags {
+		severity := "Warning"
+		if d.Severity == diag.Error {
+			severity = "Error"
+		}
+		if d.Detail != "" {
+			fmt.Fprintf(os.Stderr, "%s: %s: %s\n", severity, d.Summary, d.Detail)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", severity, d.Summary)
+		}
+	}
+	if diags.HasError() {
+		return 1
+	}
+	return 0
 }